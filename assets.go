@@ -237,7 +237,7 @@ func benchdwarf() (*asset, error) {
 	return a, nil
 }
 
-var _cronjobSh = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xcc\x56\x6b\x6f\xdb\x36\x14\xfd\x1c\xfe\x8a\x5b\xc5\x43\x5a\xd4\x94\xea\xac\x1d\xd6\x0c\xea\x96\xa4\x49\x56\xac\x51\x8a\x3c\x80\x62\x45\xb7\x50\xd2\x95\xc4\x86\x12\x05\x92\x8a\xdd\xba\xfe\xef\x03\x25\x3f\xe5\x47\x9a\x60\x05\x9a\x0f\x81\x2c\x1e\x9e\xfb\xe2\x39\xd4\xf6\x23\x2f\xe4\x85\x17\x32\x9d\x01\x1d\x10\xb2\x0d\x25\xaa\x44\xc8\xe8\x06\xb8\x86\x42\x1a\x60\xa2\xcf\x3e\x6b\x60\xb7\x8c\x0b\x16\x0a\x24\xef\x8e\xce\x8f\xdf\x9e\x1d\xfe\xe5\x5f\xf7\x33\x1e\x65\xd3\x0d\xd7\x84\x74\x86\x93\xc5\x11\x60\x94\x49\x70\x4e\x14\x33\x15\x37\xb2\xd2\x33\x62\x23\xa1\x54\x78\x8b\x85\x01\x93\x71\x0d\x3a\x52\xbc\x34\x90\x28\x99\x83\x36\x4c\x19\x5e\xa4\xc0\x13\xd0\x32\x47\x93\xd9\x1f\x28\x34\xda\x7c\xb4\xe1\x42\x00\x2f\xa0\x54\x32\x55\xa8\xb5\x43\xc8\xf9\xd9\xd9\xa5\xef\x74\x86\x7f\x9e\x9d\x1e\x8d\xbc\xbe\x54\x37\x5e\x88\x85\xa1\x91\x92\x85\x43\x70\x50\x4a\x65\xc0\x82\x6c\x71\x07\xfb\x17\x47\x96\xc8\x64\x08\x21\xd3\x28\x78\x81\x5d\x88\x31\xe1\x05\xc6\x90\xa1\xc2\x2e\x30\xad\xab\x1c\x63\x88\x32\x8c\x6e\x30\x06\x59\x19\x60\x45\x0c\x61\xc5\x85\x71\x89\xa5\xf0\x4f\x64\xcf\xed\x3d\x9f\xb0\xdb\x57\x96\x3d\xa8\x7b\x56\xe5\x21\x2a\x90\x09\x84\x58\x44\x59\xce\xd4\x8d\xee\xc2\x41\x6b\xa9\xe2\x22\xd6\x64\x1b\x0e\x59\x01\xf2\x16\x95\xe2\x31\xda\xac\x34\x42\x9f\x9b\x0c\x68\xe0\xd7\x41\x29\xf3\x41\x16\x10\xc9\x3c\xb7\x3f\x6d\xbe\x2e\x09\xfc\xdd\x17\xe4\xc0\xfe\x0b\x02\xe1\x3f\x23\x07\x81\xf0\x7b\xa4\x79\xb4\x4f\x84\x27\xf0\x01\x9c\x41\x67\x78\x71\xf5\xe6\xf2\x68\xe4\x80\x0f\xce\xc0\x81\x8f\xf0\x9b\x8d\x51\x90\xad\xfa\xbd\xef\xcc\x35\x2a\xe1\x84\x44\x31\x38\x9d\xa1\xed\xd5\xc8\x19\x93\x3c\x02\x8a\xf6\xa5\x2d\x71\x34\xcf\x50\x8f\xf7\x94\x6b\x5d\xcf\x67\x50\x62\x64\x30\x9e\xf6\x14\x62\xae\x30\x32\x52\x7d\x9e\xdb\xcc\x8b\x19\x7d\x17\x98\x31\x98\x97\xf5\xac\x8d\x6c\x9a\x3d\xdf\xe9\xd8\x25\x5b\x96\xcd\xbf\xae\x23\x75\xea\xc9\x7d\x05\xa3\xe0\x04\xd2\x6b\xb2\x35\x7f\xd4\x52\x6e\x20\x12\xb2\x40\xc8\x8c\x29\xf5\x9e\xe7\xa5\xd2\x4d\xa5\x4c\x05\x6a\x59\xa9\x08\xdd\x48\xe6\x5e\x2a\x81\x86\xa0\x50\x20\xd3\x48\x43\xc5\x8a\x28\x73\x3b\x43\x1b\x64\x04\xe3\x24\xc9\x56\x5d\x75\xe7\x77\x78\xe4\xc3\xb3\xb9\x72\x9b\x7a\xff\xbf\x40\x70\xbc\xff\xe6\xed\xd1\x6b\x4b\x3c\xe0\x06\x7a\x64\x2b\xe1\x64\x2b\x8a\x27\xeb\x9e\x56\xd1\x62\x91\xae\x97\xb3\x1b\x74\xad\x52\xef\xc8\xb2\x6e\xd5\x14\xbc\x36\xd2\x6c\xd4\x76\xf6\xdb\x70\x8e\x89\x42\x9d\x81\xe1\x65\x17\x52\x34\xa0\xf0\x96\x6b\x2e\x8b\xe6\x24\x50\x84\x54\x52\xc3\xcb\xb9\x78\xf3\xf9\xa9\x1c\xa8\x4a\xc6\x18\x4b\xf9\xa0\x09\x8d\xb7\xaf\xa9\xaf\x35\x84\x71\x3e\x09\xe3\x02\x63\x32\xa9\x2f\xe1\xf6\x20\x37\x6b\x75\x1b\xd7\x75\x71\x53\x90\x59\xfb\x96\xd9\x0d\x2f\xfd\x6b\x9b\x85\x90\x29\xd0\x02\x7a\x40\x69\x22\x55\xce\x8c\xbf\xf3\x53\xb6\x73\x6d\x9b\x79\x32\xd7\x3f\x48\xa4\xaa\x95\x01\x5a\xda\x18\x0a\x1b\x6f\x05\x96\x87\x3c\xad\xb8\xf9\xbc\xa0\x3c\x6f\x72\x16\x9b\xe3\x7f\x47\xa0\xb3\xd2\xf0\x9c\x7f\xc1\xb1\x6a\x1a\xfd\x4c\x8c\xa7\x25\xe9\x6d\x38\x96\x0a\x98\xca\x7f\x79\x0e\x21\x4f\x5d\xc1\x8d\x11\xd8\x85\x9c\xa7\x99\x81\x02\x31\x1e\x7b\x73\xc2\x07\x8d\x0d\xcd\x2c\x58\xf0\x1b\xdc\xb3\x85\x9d\x9d\xee\xbf\x7f\x77\x7e\x76\x78\xe1\x3f\xb7\x86\xc6\x22\x23\x80\x1e\xc2\x2e\x7d\x01\x94\x82\xeb\xba\x0b\xfd\xb6\x0e\x03\xf4\x0a\xe8\xad\xb5\xb4\xce\x30\x18\x59\x4b\xeb\x0c\x0f\x46\x40\xdf\xfa\x76\xf5\x93\x0c\xb5\x5b\x17\x78\xe8\x47\xb2\x48\x78\x5a\x29\x66\xb8\x2c\x74\xed\x4c\x9f\x64\xe8\x1a\x99\x0b\xa0\x11\x1c\x30\x8d\xdd\x4b\x5e\x82\xd3\xf9\xc3\x21\xe7\x57\x81\x7f\x6d\x18\x17\x40\x7b\xb0\xc0\xf4\x15\x58\xff\xc6\x6e\xd8\x19\x96\x8a\x17\x06\x3a\xbd\x91\xed\x56\x34\x6e\x0d\xb9\xb8\xdc\x3f\x7d\xe7\x3b\xda\xb0\xbc\xa4\x9d\xce\xf4\x9a\xa8\xdf\x93\xe6\xe6\xd2\x15\x37\xb8\x07\x33\x0f\x7d\xf5\xca\xfe\xb0\x88\xd1\x18\x62\x83\xd6\x1c\x16\x76\x7e\x15\x34\x20\x67\x82\x72\xc6\x30\xc3\x6b\x80\xe1\xe5\x1a\x80\x1d\xb4\x45\xd4\x0e\xd1\x86\x90\x8b\xe3\xf7\xf6\x7e\xab\xf9\x09\x89\xca\x69\x16\x13\xaf\xa0\xb2\x34\x6e\x67\x78\x71\xfc\x7e\xd4\x5a\xb6\x21\xe7\x57\x49\xc4\xcc\x38\x55\xd7\x36\xd3\x6d\xce\x4c\x5d\xd9\x32\xd5\x0c\x7b\xc9\xcb\x79\x68\x9b\x16\x53\x85\x25\xec\xfc\xf3\xf8\x60\x72\xf0\xbe\x7e\xa0\xff\x32\xfa\x65\x9f\xfe\xfd\x8c\xbe\xfc\xf8\x74\xef\xc9\xce\x42\x58\x6d\x62\xeb\xf5\xab\xe3\xde\x87\xcd\x26\x36\x4f\xd6\xce\xac\x5d\xef\xb0\x9e\xbf\xe6\x5f\xb0\x5b\x3f\xc5\x7d\xa6\x92\xd1\xb7\x35\x60\xd3\xde\x76\xdc\x06\xca\x6e\x71\x05\xef\x0a\xb8\xca\x17\x26\x5e\x5f\x03\xb5\x66\xc9\x36\x5c\x66\x08\x89\x14\x42\xf6\xad\x0c\x63\x2c\xb1\x88\xb5\xfd\x26\xb0\xda\x04\x69\xfd\x04\x78\x91\x28\xa6\x8d\xaa\x22\x53\xd9\x4f\x18\x8d\xb5\x56\xed\x5f\xca\x4d\x56\x85\x9e\xb5\xd7\x58\xed\x46\x19\xd3\xe8\xa5\x92\xd6\x09\x52\xd3\x47\x34\x34\x94\x06\xc6\xe8\x99\x3b\x53\xeb\x5e\xd8\x5f\x36\xe9\xc8\xb3\x1f\x72\xde\x53\x6f\xb7\xf7\xeb\xcb\xdd\x9f\xe1\x71\x53\x2b\x2a\x8e\xfa\xc9\xf4\xaa\x70\xbd\x86\x5b\xa1\xae\x84\xd1\x73\xf6\xda\x5e\x99\x1e\xed\xe6\x16\x7a\x8d\x61\x95\xa6\xb6\xd4\xe6\xc0\xb5\x2c\x6c\xa3\xb7\x04\x62\xe2\x2e\xf5\xd3\xcc\x5f\x02\x71\x0f\x87\x09\x84\xf5\x98\x40\x6c\x70\x99\x31\xdf\xf7\xf0\x19\x1a\x88\x1f\xd9\x6a\x02\xb1\xc1\x69\x66\x8b\x6d\xe1\x05\x62\xd9\x6a\xe6\xa8\x16\x84\xb6\x88\x6d\x11\xdf\xd7\x6a\x02\xb1\xc2\x6c\x1e\x46\xd7\xa4\xb6\xe4\x36\x2b\xcb\x18\x87\xbe\xdb\x6e\x36\x34\xe1\x4e\xbf\x99\xed\x5d\xb6\x9b\xe9\xda\x32\xb8\x65\x36\xdb\x10\x48\xca\x8b\xfa\xab\xfd\xfe\x92\x9b\x2a\x6e\x51\x70\xf7\xd1\x5b\x2d\xb7\x4d\x6a\xfb\x8e\x62\xfb\xa1\xb5\xb6\x49\x6a\x6b\x95\xb6\x42\x68\x6b\x8e\xd8\xb2\xcc\x1e\xac\xb2\x55\x22\x7b\xa8\xc6\x56\x48\x6c\x9d\xc2\xbe\x45\x60\x6b\x8b\xbf\x53\x5e\x1b\xd4\xd5\x16\xd7\x1a\x6d\xfd\x17\x00\x00\xff\xff\x2d\x69\x18\xf3\x5d\x11\x00\x00")
+var _cronjobSh = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xcc\x56\x6d\x4f\xdc\xb8\x1a\xfd\x8c\x7f\xc5\xd3\x30\x57\xb4\xea\x38\xe9\x70\xdb\xab\x5b\x56\x61\x17\x28\xb0\xd5\x96\x50\xf1\x22\x55\x5b\x75\x17\x27\x79\x92\xb8\x38\x71\x64\x3b\x0c\xed\x74\xfe\xfb\xca\xc9\xbc\x64\xc2\xcc\x50\xd0\x56\x2a\x1f\x98\x24\x3e\x3e\xcf\x9b\xcf\x49\x36\x9f\x78\x21\x2f\xbc\x90\xe9\x0c\xe8\x2d\x21\x9b\x50\xa2\x4a\x84\x8c\xae\x81\x6b\x28\xa4\x01\x26\x86\xec\x8b\x06\x76\xc3\xb8\x60\xa1\x40\xf2\xfe\xf0\xec\xe8\xdd\xe9\xc1\x1f\xfe\xd5\x30\xe3\x51\x36\xdb\x70\x45\x48\x6f\x34\x5d\x1c\x03\x46\x99\x04\xe7\x58\x31\x53\x71\x23\x2b\x3d\x27\x36\x12\x4a\x85\x37\x58\x18\x30\x19\xd7\xa0\x23\xc5\x4b\x03\x89\x92\x39\x68\xc3\x94\xe1\x45\x0a\x3c\x01\x2d\x73\x34\x99\xbd\x41\xa1\xd1\xe6\xa3\x0d\x17\x02\x78\x01\xa5\x92\xa9\x42\xad\x1d\x42\xce\x4e\x4f\x2f\x7c\xa7\x37\xfa\xfd\xf4\xe4\x70\xec\x0d\xa5\xba\xf6\x42\x2c\x0c\x8d\x94\x2c\x1c\x82\xb7\xa5\x54\x06\x2c\xc8\x16\xb7\xbf\x77\x7e\x68\x89\x4c\x86\x10\x32\x8d\x82\x17\xd8\x87\x18\x13\x5e\x60\x0c\x19\x2a\xec\x03\xd3\xba\xca\x31\x86\x28\xc3\xe8\x1a\x63\x90\x95\x01\x56\xc4\x10\x56\x5c\x18\x97\x58\x0a\xff\x58\x0e\xdc\xc1\xcb\x29\xbb\x7d\x64\xd9\x83\xba\x67\x55\x1e\xa2\x02\x99\x40\x88\x45\x94\xe5\x4c\x5d\xeb\x3e\xec\x77\x96\x2a\x2e\x62\x4d\x36\xe1\x80\x15\x20\x6f\x50\x29\x1e\xa3\xcd\x4a\x23\x0c\xb9\xc9\x80\x06\x7e\x1d\x94\x32\x1f\x64\x01\x91\xcc\x73\x7b\x6b\xf3\x75\x49\xe0\x6f\xbf\x22\xfb\xf6\x5f\x10\x08\xff\x05\xd9\x0f\x84\x3f\x20\xcd\xa5\xbd\x22\x3c\x81\x8f\xe0\xdc\xf6\x46\xe7\x97\x6f\x2f\x0e\xc7\x0e\xf8\xe0\xdc\x3a\xf0\x09\x7e\xb1\x31\x0a\xb2\x51\x3f\xf7\x9d\x56\xa3\x12\x4e\x48\x14\x83\xd3\x1b\xd9\x5e\x8d\x9d\x09\xc9\x13\xa0\x68\x1f\xda\x12\xc7\x6d\x86\x7a\xbc\x27\x5c\xeb\x7a\x3e\xb7\x25\x46\x06\xe3\x59\x4f\x21\xe6\x0a\x23\x23\xd5\x97\xd6\x66\x5e\xcc\xe9\xfb\xc0\x8c\xc1\xbc\xac\x67\x6d\x64\xd3\xec\x76\xa7\x63\x97\x6c\x58\x36\xff\xaa\x8e\xd4\xab\x27\xf7\x0d\x8c\x82\x63\x48\xaf\xc8\x46\xfb\xa8\xa5\xdc\x40\x24\x64\x81\x90\x19\x53\xea\x1d\xcf\x4b\xa5\x9b\x4a\x99\x0a\xd4\xb2\x52\x11\xba\x91\xcc\xbd\x54\x02\x0d\x41\xa1\x40\xa6\x91\x86\x8a\x15\x51\xe6\xf6\x46\x36\xc8\x18\x26\x49\x92\x8d\xba\xea\xde\xaf\xf0\xc4\x87\x17\xad\x72\x9b\x7a\xff\xbd\x40\x70\xb4\xf7\xf6\xdd\xe1\x1b\x4b\x7c\xcb\x0d\x0c\xc8\x46\xc2\xc9\x46\x14\x4f\xd7\x3d\xad\xa2\xc5\x22\x5d\x2f\x67\xd7\xe8\x5a\xa5\xde\x93\x65\xdd\xaa\x19\x78\x65\xa4\xf9\xa8\xed\xec\x37\xe1\x0c\x13\x85\x3a\x03\xc3\xcb\x3e\xa4\x68\x40\xe1\x0d\xd7\x5c\x16\xcd\x49\xa0\x08\xa9\xa4\x86\x97\xad\x78\xed\xfc\x54\x0e\x54\x25\x13\x8c\xa5\x7c\xd4\x84\x26\xdb\x57\xd4\xd7\x19\xc2\x24\x9f\x84\x71\x81\x31\x99\xd6\x97\x70\x7b\x90\x9b\xb5\xba\x8d\xab\xba\xb8\x2e\xc8\x0c\xb5\x84\xdd\xf0\xd2\xbf\xb2\x59\x08\x99\x02\x2d\x60\x00\x94\x26\x52\xe5\xcc\xf8\x5b\xff\xc9\xb6\xae\x6c\x33\x8f\x5b\xfd\x83\x44\xaa\x5a\x19\xa0\xa5\x8d\xa1\xb0\xf1\x56\x60\x79\xc8\xd3\x8a\x9b\x2f\x0b\xca\xf3\xa6\x67\xb1\x39\xfe\xf7\x04\x3a\x2d\x0d\xcf\xf9\x57\x9c\xa8\xa6\xd1\xcf\xd4\x78\x3a\x92\xde\x84\x23\xa9\x80\xa9\xfc\x7f\x2f\x21\xe4\xa9\x2b\xb8\x31\x02\xfb\x90\xf3\x34\x33\x50\x20\xc6\x13\x6f\x4e\xf8\x6d\x63\x43\x73\x0b\x16\xfc\x1a\x77\x6c\x61\xa7\x27\x7b\x1f\xde\x9f\x9d\x1e\x9c\xfb\x2f\xad\xa1\xb1\xc8\x08\xa0\x07\xb0\x4d\x5f\x01\xa5\xe0\xba\xee\x42\xbf\xad\xc3\x00\xbd\x04\x7a\x63\x2d\xad\x37\x0a\xc6\xd6\xd2\x7a\xa3\xfd\x31\xd0\x77\xbe\x5d\xfd\x2c\x43\xed\xd6\x05\x1e\xf8\x91\x2c\x12\x9e\x56\x8a\x19\x2e\x0b\x5d\x3b\xd3\x67\x19\xba\x46\xe6\x02\x68\x04\xfb\x4c\x63\xff\x82\x97\xe0\xf4\x7e\x73\xc8\xd9\x65\xe0\x5f\x19\xc6\x05\xd0\x01\x2c\x30\x7d\x03\x36\xbc\xb6\x1b\xb6\x46\xa5\xe2\x85\x81\xde\x60\x6c\xbb\x15\x4d\x5a\x43\xce\x2f\xf6\x4e\xde\xfb\x8e\x36\x2c\x2f\x69\xaf\x37\x7b\x4d\xd4\xcf\x49\xf3\xe6\xd2\x15\x37\xb8\x03\x73\x0f\xdd\xdd\xb5\x37\x16\x31\x9e\x40\x6c\xd0\x9a\xc3\xc2\xce\x2e\x83\x06\xe4\x4c\x51\xce\x04\x66\x78\x0d\x30\xbc\x5c\x01\xb0\x83\xb6\x08\xfb\x7b\x07\x42\xce\x8f\x3e\xf8\xce\x84\x9f\x90\xa8\x9c\x65\x31\xf5\x0a\x2a\x4b\xe3\xf6\x46\xe7\x47\x1f\xc6\x9d\x65\x1b\xb2\xbd\x4a\x22\x66\x26\xa9\xba\xb6\x99\x6e\x73\x66\x76\x77\x97\x52\xcd\xb1\x17\xbc\x6c\x43\xbb\xb4\x98\x2a\x2c\x61\xeb\xaf\xa7\xfb\xd3\x83\xf7\xed\x23\xfd\x9b\xd1\xaf\x7b\xf4\xcf\x17\xf4\xf5\xa7\xe7\x3b\xcf\xb6\x16\xc2\x6a\x13\x5b\xaf\x5f\x1e\xf7\x21\x6c\x36\xb1\x36\x59\x37\xb3\x6e\xbd\xa3\x7a\xfe\x9a\x7f\xc5\x7e\x7d\x15\x0f\x99\x4a\xc6\xdf\xd7\x80\x75\x7b\xbb\x71\x1b\x28\xbb\xc1\x25\xbc\x4b\xe0\x2a\x5f\x98\x78\xfd\x1a\xa8\x35\x4b\x36\xe1\x22\x43\x48\xa4\x10\x72\x68\x65\x18\x63\x89\x45\xac\x41\x16\xb5\x36\x41\x5a\x3f\x01\x5e\x24\x8a\x69\xa3\xaa\xc8\x54\xf6\x13\x46\x63\xad\x55\xfb\x97\x72\x93\x55\xa1\x67\xed\x35\x56\xdb\x51\xc6\x34\x7a\xa9\xa4\x75\x82\xd4\x0c\x11\x0d\x0d\xa5\x81\x09\x7a\xee\xce\xd4\xba\x17\x0e\xef\x9a\x74\xe4\xd9\x0f\x39\xef\xb9\xb7\x3d\xf8\xff\xeb\xed\xff\xc2\xd3\xa6\x56\x54\x1c\xf5\xb3\xd9\xab\xc2\xf5\x1a\x6e\x85\xba\x12\x46\xb7\xec\xb5\xbb\x32\x3b\xda\xcd\x5b\xe8\x0d\x86\x55\x9a\xda\x52\x9b\x03\xd7\xb1\xb0\xb5\xde\x12\x88\xa9\xbb\x04\x62\xc1\x5f\x02\xf1\x00\x87\x09\x84\xf5\x98\x40\xac\x71\x99\x40\xfc\x30\x9f\xa1\x81\xf8\x99\xad\x26\x10\x6b\x9c\x66\xbe\xd8\x15\x5e\x20\xee\x5a\x4d\x8b\x6a\x41\x68\x8b\xd8\x0e\xf1\x43\xad\x26\x10\x4b\xcc\xe6\x71\x74\x4d\x6a\x77\xdc\x66\x69\x19\x93\xd0\xf7\xdb\xcd\x9a\x26\xdc\xeb\x37\xf3\xbd\x77\xed\x66\xb6\x76\x17\xdc\x31\x9b\x4d\x08\x24\xe5\x45\xfd\xd5\xfe\x70\xc9\xcd\x14\xb7\x28\xb8\x87\xe8\xad\x96\xdb\x3a\xb5\xfd\x40\xb1\xfd\xd4\x5a\x5b\x27\xb5\x95\x4a\x5b\x22\xb4\x15\x47\xec\xae\xcc\x1e\xad\xb2\x65\x22\x7b\xac\xc6\x96\x48\x6c\x95\xc2\xbe\x47\x60\x2b\x8b\xbf\x57\x5e\x6b\xd4\xd5\x15\xd7\x0a\x6d\xfd\x33\x00\x2d\x69\x18\xf3\x5d\x11\x00\x00")
 
 func cronjobShBytes() ([]byte, error) {
 	return bindataRead(
@@ -252,7 +252,7 @@ func cronjobSh() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "cronjob.sh", size: 4445, mode: os.FileMode(493), modTime: time.Unix(1586977140, 0)}
+	info := bindataFileInfo{name: "cronjob.sh", size: 4445, mode: os.FileMode(493), modTime: time.Unix(1792402897, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -297,7 +297,7 @@ func cmpclSh() (*asset, error) {
 	return a, nil
 }
 
-var _cmpclPhaseSh = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xd4\x95\xdf\x6f\xdb\x36\x10\xc7\x9f\xcb\xbf\xe2\x5b\x46\x6b\x5f\x4a\xa9\xc2\x80\x00\xcb\xa2\x6c\x89\xeb\xa0\xc3\x32\xa7\x68\xb2\xbd\x0c\xdb\x4c\x51\x27\x89\x0d\x45\x0a\x24\x1d\x67\xf0\xfc\xbf\x0f\x92\xf3\xa3\x4b\xbc\x34\xd9\xdb\xde\x6c\xde\xdd\xf7\x8e\x77\x1f\x1d\x77\x5e\x66\xa5\xb6\x59\x29\x43\x0b\x71\xc5\xd8\x0e\x7e\x91\x5e\x4b\x1b\xe1\x6a\xa8\xae\x57\x26\x0d\x2d\x6a\xe7\xa1\x5c\xd7\x4b\xaf\x6d\x83\xb3\xb3\x43\xf4\xad\x0c\x84\xa8\x3b\x6d\x9b\x30\xda\x25\x26\x27\x90\xb6\x82\x8e\x01\xba\xeb\xa8\xd2\x32\x12\x7a\x4f\x15\x29\x0a\xc1\xf9\x74\x90\x6f\x74\x44\x4d\x51\xb5\xe0\x6d\x8c\x7d\xd8\xcb\xb2\xc6\xa5\x8d\x73\x8d\xa1\xe0\x16\x5e\x51\xaa\x5c\x97\x35\x8e\xc3\x53\x1d\x32\xd5\x4a\xdb\x50\xc8\x76\xf3\x2c\xff\x66\x77\x77\x37\xcf\xbe\xc6\xab\x57\xa3\x8c\x6a\x49\x5d\xb8\x45\xc4\xf1\xf4\x7c\xf2\xfe\x8f\xf7\xd3\xc3\x77\x8c\xe9\x1a\xbf\x22\xd9\x81\x30\x11\x39\x7e\xc3\xb7\x88\x2d\x59\x06\x90\x6a\xdd\xdd\x8d\xf8\x3f\xc4\xf7\xad\x3d\xc8\xf6\x95\x39\xc8\xf6\x7b\x19\x55\x7b\xc0\xc1\x4b\xb2\x51\xb8\x3e\x6a\x67\x03\x1f\xe2\xaf\x74\x44\xce\x6a\x7d\x93\x63\x95\xef\xbd\xdd\xcb\xd7\x28\xc0\x05\x7f\x90\x89\x1f\x6b\x1f\x22\x7a\xe9\x65\x47\x91\x3c\x42\xeb\x16\xa6\x42\x49\x90\x63\xf5\x51\x36\x70\x1e\xa5\x97\x56\xb5\xf7\x12\x28\x53\xf0\x24\xe7\x2c\xb4\xba\x8e\x8c\x7d\x3c\x3d\x3d\x2f\xe6\xfd\xb2\x9a\x33\xba\xea\x9d\x8f\x18\x4e\xa0\xcc\xd0\xd0\x9e\x7c\x6d\x9c\xba\x80\x0e\xb0\x2e\x42\x9a\xa5\xfc\x33\x40\x5e\x4a\x6d\x64\x69\x88\x7d\x98\x7e\x3c\x3e\x39\x9d\xfc\x58\xcc\x97\xad\x56\xed\x6d\xc0\x7c\x88\x9e\x8d\x61\x8b\xae\x24\x3f\x4c\xbc\x24\xab\xda\x4e\xfa\x8b\xf0\x06\x47\xf7\x4c\x0b\x6d\xaa\xc0\x76\x30\x91\x16\xee\x92\xbc\xd7\x15\x0d\x57\x0e\x84\xa5\x8e\x2d\xc4\xac\x18\xe7\x2f\x64\x01\x67\x07\x5e\xba\xe1\xaf\xd1\x96\x52\x36\x2b\xde\xb2\xa3\x22\x67\x4c\x55\xe0\xc9\x6a\xa8\x7f\xcd\xaf\x3b\x29\x08\x8d\x13\xce\x54\x77\x4d\x7c\xe1\x3b\x08\x5f\x5f\x9f\x8f\x3d\x19\x27\x6e\x9c\x25\x3c\xce\xcd\x4d\xcc\x66\x48\xdf\xe1\x65\x81\xb7\x9f\x09\x8f\xc3\x79\xa6\x16\x8e\x0f\x7f\x38\x99\xbe\x63\x2f\xee\x46\xa4\xaa\x6b\x5b\x16\xbc\x62\xcf\x80\x9a\x27\x2b\x65\xd6\xfc\x8b\xe5\x3d\x4f\x0e\xa8\xa5\x36\x54\x7d\x5e\xe2\xbf\x7c\x22\xbf\xe7\x5f\x6e\xcd\xb6\xa8\x2d\x19\xd2\xac\x93\x17\x94\x0e\xcb\xe3\x51\xcd\xa3\xc3\xb3\x29\x6e\x5d\xb7\x74\xd3\x99\x2a\xca\xa6\x98\x0f\xc9\x8d\x6b\x20\x2c\x72\x08\x51\x3b\xdf\xc9\x58\xbc\xfe\xaa\x7d\x7d\x4b\xfe\xc6\xf5\x11\x8e\x2c\x2d\xb7\x72\x64\x69\x79\xdb\x95\x27\x8e\x7e\x08\x79\x1a\x46\xd7\x79\x1f\xb6\x68\xc3\x89\xa5\xe5\xc8\xc9\xff\x0a\x94\xff\x82\xc9\x16\xf5\x27\x53\x72\x07\xc8\x43\x11\x4b\xcb\xa7\x02\xb2\x71\x65\xec\xec\xfc\xf0\xa7\x0f\x05\x4f\x12\x7e\x63\x19\x4f\xee\x91\x93\xac\x6e\xd6\xe3\x7a\x58\x7e\x11\xe2\x67\x88\xcb\x61\x97\x25\xab\xd9\x7a\xd8\x65\xc9\xea\x68\x0d\x71\x52\x0c\xd6\x4f\xae\x0c\xe9\x98\x5f\x15\xa7\xa6\x12\xe3\x2b\xf8\x66\x46\xcb\xcd\x2f\x88\x49\xa1\x9c\xad\x75\xb3\xf0\x72\x7c\x38\x84\xea\xfa\x4f\xae\x4c\xa3\xeb\x0c\x78\xf2\x3d\xc7\x5f\x88\x44\x9b\xe7\x33\xa4\xc9\x6a\xac\x69\x3d\x68\xb2\xf1\x4c\x44\xdd\x51\xc0\xc1\x03\x0f\x15\x2e\x19\xfb\x3b\x00\x00\xff\xff\x10\x8d\xa3\x83\xae\x07\x00\x00")
+var _cmpclPhaseSh = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xd4\x55\xdf\x53\xe3\x36\x10\x7e\x3e\xfd\x15\xdf\x19\xf7\x78\x39\xd9\x78\x3a\xc3\x4c\x29\xa6\x05\x0e\xe6\x3a\xa5\x81\xe1\x47\x5f\x3a\x6d\xa3\xc8\x6b\x5b\x87\x2c\x79\x24\x05\xd3\xc9\xe5\x7f\xef\xd8\x09\xe1\x80\x94\x83\xbe\xdd\x4b\x62\x6b\x77\xbf\xdd\xfd\xf6\xf3\x6a\xe3\x6d\x3a\x51\x26\x9d\x08\x5f\x83\xdf\x32\xb6\x81\xdf\x85\x53\xc2\x04\xd8\x12\xb2\x69\xa5\x4e\x7c\x8d\xd2\x3a\x48\xdb\xb4\xc2\x29\x53\xe1\xe2\x62\x1f\x6d\x2d\x3c\x21\xa8\x46\x99\xca\x0f\x76\x81\xc3\x13\x08\x53\x40\x05\x0f\xd5\x34\x54\x28\x11\x08\xad\xa3\x82\x24\x79\x6f\x5d\xd2\xc3\x57\x2a\xa0\xa4\x20\x6b\x44\x75\x08\xad\xdf\x49\xd3\xca\x26\x95\xb5\x95\x26\x6f\xa7\x4e\x52\x22\x6d\x93\x56\x36\x82\xa3\xd2\xa7\xb2\x16\xa6\x22\x9f\x6e\x67\x69\xf6\xc3\xf6\xf6\x76\x96\x7e\x8f\x77\xef\x06\x18\x59\x93\xbc\xb6\xd3\x80\xe3\xa3\xcb\xc3\x8f\x7f\x7f\x3c\xda\xff\xc0\x98\x2a\xf1\x07\xe2\x0d\x70\x1d\x90\xe1\x4f\xfc\x88\x50\x93\x61\x00\xc9\xda\xde\x77\x14\x3d\x00\xdf\x35\x66\x2f\xdd\x95\x7a\x2f\xdd\x6d\x45\x90\xf5\x5e\x84\x68\x42\x26\x70\xdb\x06\x65\x8d\x8f\xfa\xf8\x5b\x15\x90\xb1\x52\xdd\xe5\x98\x65\x3b\x5b\x3b\xd9\x1c\x39\x22\x1e\x3d\xc9\x14\x1d\x2b\xe7\x03\x5a\xe1\x44\x43\x81\x1c\x7c\x6d\xa7\xba\xc0\x84\x20\x86\xea\x83\xa8\x60\x1d\x26\x4e\x18\x59\x3f\x4a\x20\x75\x1e\xc5\x59\xc4\x7c\xad\xca\xc0\xd8\xf9\xe9\xe9\x65\x3e\x6e\xbb\x62\xcc\xe8\xb6\xb5\x2e\xa0\x3f\x81\xd4\x3d\xa1\x2d\xb9\x52\x5b\x79\x0d\xe5\x61\x6c\x80\xd0\x9d\xf8\xc7\x43\xdc\x08\xa5\xc5\x44\x13\x3b\x3b\x3a\x3f\x3e\x39\x3d\xfc\x35\x1f\x77\xb5\x92\xf5\x2a\x60\xdc\x47\x8f\x86\xb0\x69\x33\x21\xd7\x4f\x7c\x42\x46\xd6\x8d\x70\xd7\xfe\x3d\x0e\x1e\x99\xa6\x4a\x17\x9e\x6d\xe0\x50\x18\xd8\x1b\x72\x4e\x15\xd4\xb7\xec\x09\x9d\x0a\x35\xf8\x28\x1f\xe6\xcf\x45\x0e\x6b\x7a\xbd\x34\xfd\xab\x56\x86\x12\x36\xca\xb7\xd8\x41\x9e\x31\x26\x0b\x44\xf1\xac\xaf\x7f\x1e\x2d\x99\xe4\x84\xca\x72\xab\x8b\x7b\x12\xdf\xb8\x06\xdc\x95\xcb\xf3\x81\x93\x61\xe2\xda\x1a\xc2\xf3\xba\xb9\x8b\x59\x0c\xe9\x27\xbc\xcd\xb1\xf5\x05\xf0\x30\x9c\x57\x62\xe1\x78\xff\x97\x93\xa3\x0f\xec\xcd\xfd\x88\x64\xb1\xb4\xa5\xde\x49\xf6\x0a\x51\x47\xf1\x4c\xea\x79\xf4\xd5\xf2\x5e\x07\x07\x94\x42\x69\x2a\xbe\x2c\xf1\x3f\x3e\x91\xbf\xb2\xaf\x53\xb3\x2e\x6a\x4d\x86\x24\x6d\xc4\x35\x25\xfd\xf2\x78\x16\xf3\x60\xff\xe2\x08\x2b\xd7\x35\x6c\x5a\x5d\x04\x51\xe5\xe3\x3e\xb9\xb6\x15\xb8\x41\x06\xce\x4b\xeb\x1a\x11\xf2\xcd\xef\xea\xcd\x95\xf2\x17\xae\xcf\xe8\xc8\x50\xb7\x56\x47\x86\xba\x15\x2b\x2f\x1c\x7d\x1f\xf2\x32\x19\x2d\xf3\x3e\xa5\x68\xa1\x13\x43\xdd\xa0\x93\x6f\x4a\x28\xff\x47\x26\x6b\xd0\x5f\xac\x92\x95\xd7\x1a\x10\x43\xdd\x4b\x05\xb2\x70\x65\xec\xe2\x72\xff\xb7\xb3\x3c\x8a\xe3\xe8\xce\x32\x9c\x3c\x52\x4e\x3c\xbb\x5b\x8f\xf3\x7e\xf9\x05\xf0\x2b\xf0\x9b\x7e\x97\xc5\xb3\xd1\xbc\xdf\x65\xf1\xec\x60\x0e\x7e\x92\xf7\xd6\x4f\x76\xe2\x93\x21\xbf\xcc\x4f\x75\xc1\x87\x5b\xf0\xfd\x88\xba\xc5\x13\xf8\x61\x2e\xad\x29\x55\x35\x75\x62\xb8\x38\xb8\x6c\xda\x4f\x76\x92\x04\xdb\x68\x44\xf1\xcf\x11\x3e\x23\x10\x2d\xae\x4f\x9f\xc4\xb3\xa1\xa6\x79\x8f\xc9\xce\xaf\x46\xf9\x38\x08\xa5\xc1\x33\x3c\xc8\xf6\x19\xa2\xbb\x06\x97\xd8\x9c\xb5\x4e\x99\x80\x38\x9b\xf7\x0d\xf7\xac\x9d\x91\x5b\x26\x0f\x36\x08\xed\xa1\xcc\xfd\x16\xc7\x82\x20\x08\x47\xab\xf3\x34\x9e\x9d\x5f\x8d\xe6\xc9\xaa\xfe\x64\xf8\xf5\xc3\xee\x7e\xe0\xb1\xea\x6b\xe9\xc1\x86\x3f\x1e\x54\x43\x1e\x7b\x4f\x7a\x90\xfe\x86\xb1\x7f\x07\x00\xae\xc7\x68\x9a\x50\x08\x00\x00")
 
 func cmpclPhaseShBytes() ([]byte, error) {
 	return bindataRead(
@@ -312,7 +312,7 @@ func cmpclPhaseSh() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "cmpcl-phase.sh", size: 1966, mode: os.FileMode(493), modTime: time.Unix(1586979444, 0)}
+	info := bindataFileInfo{name: "cmpcl-phase.sh", size: 2128, mode: os.FileMode(493), modTime: time.Unix(1792398780, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -337,7 +337,7 @@ func tweetResults() (*asset, error) {
 	return a, nil
 }
 
-var _benchmarksAllToml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xc4\x59\x7d\x6f\xdc\x36\xf2\xfe\x7f\x3f\xc5\xc0\xfe\x2d\xda\x04\x59\xbd\x50\xda\xb7\xdf\xc1\xc0\x5d\xea\x36\x97\x6b\xdc\x18\xb1\xeb\x1e\x60\xb8\x0b\xae\x34\x96\xd8\x95\x48\x1d\x49\xad\xed\x20\x5f\xea\xfe\xb8\x2f\xd0\x4f\x76\xa0\xde\xd7\xb1\x76\x37\xeb\xe6\x0a\xb4\x8e\xc7\x7c\xf8\xcc\x43\xce\x90\x33\x92\x8e\xe1\x35\xf2\x20\x4e\xa9\x5c\x29\xd0\x31\xd5\xb0\xcc\x59\x12\xbe\x02\xca\xc3\xd2\x96\x39\x87\x7f\xe5\x2c\x58\x25\x0f\x80\x5c\xe4\x51\x6c\x0d\x8e\xe1\x32\x46\xe0\x82\xa9\x07\x10\x1c\x15\x50\x89\x90\x30\xa5\x31\x04\xaa\x41\xc7\x08\x4b\xa1\xb5\x48\x07\x83\x63\x40\x1d\x84\xb0\x4c\x70\x8d\x83\xc1\xf5\x75\xeb\xee\xe6\x66\x00\xf0\x13\x4d\x11\x4e\xe0\x28\xcc\x95\x66\x7c\xb1\x94\x82\x86\x01\x55\xfa\x68\x00\xf0\x01\x33\x61\xc6\x22\xa6\xe3\x7c\x69\x05\x22\xb5\x4b\x98\x1d\x89\xd1\x06\xb2\xb3\x86\x13\x38\x6a\xac\xa3\xdd\x0e\xe3\x3c\xa5\x9c\x7d\xc4\x9d\xfe\xba\xc0\x03\xdc\xc9\x40\x8a\xbb\x04\x1f\x16\x29\x6a\xc9\x02\xd5\xe3\xaf\x86\x19\x8f\x1d\xe4\x01\x0e\x55\x2c\x32\x76\xfb\xb0\x50\x54\xd2\x94\xf6\xb8\xbb\x28\x41\x76\x0b\x3a\xc0\x13\xbd\x53\x0b\x89\x4a\xff\xa6\x04\xef\xf1\x43\xef\x94\xf9\x7f\xa4\xc2\xd5\x28\x12\x76\x26\xd9\x9a\x6a\xb4\x33\x29\xb4\x08\x44\x62\x77\xa7\x3f\x43\xc1\x7d\x9a\x3c\x43\x40\x35\xfb\x40\xff\x46\xbe\xcc\x82\x03\xfd\x77\x66\x3f\xc3\x7f\xae\xd9\xa1\x1b\x60\xa6\xdb\x5d\x8e\x03\x64\xac\x28\xff\xc8\x7a\xfc\xdf\x26\x94\x47\x09\x6a\xbb\x00\x19\x09\xcb\x96\xf2\x20\x6f\xa8\x63\x94\x98\xa7\x8b\x25\xd3\x5b\x16\x5e\xc3\xcc\x89\x6a\x7e\x0f\x44\x9a\x0a\x6e\x77\x66\x1e\xf7\x4b\xe8\x55\x67\xbd\xbc\x44\x73\xff\xec\xa1\x51\x69\x21\x69\xd4\x77\xcb\x14\x30\x95\x19\xac\xad\xee\xa8\x4c\xed\x0e\xbe\xc7\xf9\xb7\xff\x10\x8c\x7f\xba\xc8\x12\xa6\x2f\x25\xe2\x8b\x23\x38\x86\xc2\x3a\x7f\x90\x34\x65\x21\x28\xc4\x54\x81\x16\x10\xd3\x35\x02\x85\x65\x1e\x19\x65\x42\x5f\x50\x1e\x2e\xc5\x3d\x86\x70\x02\x5a\xe6\x08\xc7\xf0\x8b\xe0\xdf\x68\x08\xa4\x50\x6a\x14\x88\x34\x63\x09\x9a\x99\xef\x18\xcf\xef\x41\x70\x38\xa3\xc1\xfb\x8b\x7d\xd6\x89\x3a\xa6\x2a\xfe\xb2\x50\x70\x85\x5c\xe5\xca\x6e\xe7\x6e\x09\xc5\x57\xd6\x1f\x08\xb9\x35\x48\x9f\xab\x97\x5b\x83\x74\xfd\xf6\xfc\x87\x9b\x4f\xdf\xc5\x94\xf1\x5f\x8a\x10\x15\xbf\x7e\x40\x1a\x42\x20\xa9\x8a\x4d\xe9\x54\x20\x6e\xc1\xb5\x5c\xc7\x28\x25\x8e\x3b\x1b\x39\xde\xc8\x9d\xec\x95\x56\x31\xf5\xbe\x48\xae\x7c\xc8\xb4\xb0\xeb\x69\xcf\x39\x75\x18\x30\xec\xab\x62\xdb\x7c\x37\xf3\xfe\xdc\x20\xaf\xd3\x2f\x0d\xb3\x5d\x4e\xf9\xf3\x64\x6b\xc9\xbe\x28\x37\x6b\xfc\x9f\xa7\xf8\x2e\x66\x2a\x43\xb9\x1e\x7f\x89\xec\x6a\x92\xbd\x31\xf9\x7f\xbf\x06\x91\x30\xca\x17\xa1\xca\xba\xda\x97\x79\x18\x32\x6e\x99\xcb\xaa\x18\xb7\xab\xf1\x43\xfa\xb3\x0c\xef\x15\xe9\xdb\x98\x48\x70\x4c\x96\xa6\x1c\x14\x30\x7b\xd1\x54\x4b\x7b\x8b\xbf\x0f\x39\xdf\xe6\x32\x65\x9c\x89\x1e\x8f\xc5\x58\xf5\x33\x48\x43\x83\x32\xc5\xad\xe0\xe7\x82\xa3\xb9\xbb\x4e\x8b\x3d\x35\xcf\x03\x3a\x46\x85\xa0\x0d\xe0\x2f\xc6\x78\x80\x98\xf2\xe8\x95\x69\xf5\xad\x7e\x79\x6f\x50\xbf\x5f\xfe\x86\x81\xbe\xfe\xf5\xca\x25\xe3\x1b\xc3\xc9\x85\x86\x37\xa8\x3f\x9d\xe7\xfa\xd3\x3b\xa6\xf4\x00\xe0\x4d\xf0\x3d\x5f\xc3\x09\x5c\x1f\xbd\x79\xef\xba\xee\xd9\xfb\xd3\x9f\xdf\x7d\x7f\x22\xf8\xd1\xcd\x96\xb5\x45\x82\x9b\x56\x20\xa1\x6a\xc1\xa9\x66\xeb\xcd\x93\x62\x06\x2d\x21\x23\x7b\xed\xda\x85\x61\x1b\x64\xf9\xeb\xd6\x02\x7f\x86\x61\xad\x92\x26\x09\x34\x51\x50\x3b\xa5\x24\x34\xa3\xc1\x6a\x3f\x31\x25\x76\xa7\x9c\xd3\x08\x71\x6d\x5b\x2f\xaf\x53\x7d\x73\xed\x12\xcf\xbf\xd9\x16\xed\x52\x46\x4a\xf5\x0e\xe7\x15\xa2\xaf\x82\x59\x2f\xaf\x30\xb0\x5e\xba\x8e\xe3\x38\xdf\xfe\xdf\xa7\xeb\x5f\x9d\x9b\x17\x9f\xac\x97\x67\x79\x62\xbd\x3c\x45\xae\xb0\x1c\xfb\xfb\x8b\xad\xc9\x8e\xe9\x1a\x65\x4f\xea\x9d\x51\xa5\x51\xa6\x8c\x87\xca\x6e\x81\x07\x1c\xa9\x38\x8f\xc4\x22\xc6\x24\x43\xd9\x57\x99\x22\x61\x40\x4c\xd8\xe6\x1f\xbb\x83\xdd\x72\xc1\x3c\x9d\x8f\x5b\xb3\xb1\x54\x92\x47\x22\x61\xcb\xfd\x94\xb4\xd8\x2f\x56\xb2\xe3\x64\x14\x5a\xb6\x3f\x75\x6e\x6a\xd9\xe7\xb9\xf3\x39\x5a\x24\x2a\x91\xcb\xa0\xb7\x7b\xd8\x54\xb3\x81\xfe\xba\x7a\x16\x59\x7f\x8b\xde\x23\xca\xce\xb6\x77\xe9\xcf\x53\x16\xeb\x34\xd1\x98\x66\x09\xd5\xfb\xe9\xd2\x59\x62\x33\xae\x51\x72\x9a\xd8\x91\x58\xd4\x93\x95\xfd\x98\xea\xab\xe8\x8d\x44\x12\xd6\x2c\xbb\xd3\x8c\xca\x55\x9e\xd9\xdd\x39\x5f\x45\x94\x89\x50\x46\xa5\xea\xbd\x7f\xf6\xdd\xc2\x4d\xa2\xc3\xb4\x02\x9c\x32\x45\x97\x09\x86\x27\x55\xa3\x42\x1c\xe2\x8e\x1c\x7f\xe4\xfa\x70\x69\x3a\x20\xb8\xa3\x0a\x28\x07\x94\x52\x48\x53\x6c\x39\xe3\x11\x7c\x13\x09\x88\x50\x7f\xf3\x0a\x94\x0e\x51\x4a\x38\x81\xf2\x2f\xff\x0f\xa9\x08\xf3\x04\xa1\x6f\x41\x7f\xcd\xb3\x48\xd2\x10\xe1\x56\xe4\x3c\x84\x6f\xd7\x8e\x35\x23\x96\xf3\xe2\x15\x2c\x73\x0d\xa1\x40\x55\x54\xb7\x40\x70\x4d\x19\x07\x53\x88\x68\xd4\x4f\xb7\xd7\xfe\xec\x0a\x09\x73\x67\x7d\x6f\x8a\x36\x9d\x25\x94\x47\xca\xae\xe1\x5f\xeb\x66\xa4\x21\x06\x22\xdc\xb7\x68\x94\x4b\xb4\x1f\xcf\xfb\xa3\xc5\xad\x66\x6a\x41\xb3\x8d\xd7\x29\xab\x99\xb2\x98\xb0\x57\xf9\x12\x25\xc7\x62\xcb\x57\x91\x4d\x33\x66\x9b\x4e\x8c\xf1\x68\x5b\x6f\xf8\x2c\x21\x2a\x88\xd1\x64\x99\x0c\x68\x10\xe3\x6e\x4d\x0d\xde\x6e\x26\xfc\xd1\xb2\xf2\x9c\x85\x3d\xf1\x52\x54\x0b\xc9\xec\x48\x58\x06\xb4\x7d\x53\x76\xf5\x4f\x5a\x64\x62\x47\x03\x15\x49\x9a\xc5\xb6\x01\x3e\xcf\x55\x46\x75\xbc\x97\x2b\x03\x7c\x9e\xab\x40\xa4\x69\xce\x99\x7e\xd8\xcb\x5f\x83\xde\xe6\xf4\x9d\xc8\xd7\x94\xf1\x53\x26\x31\xd0\x18\x9e\xe5\x89\x66\x59\x82\xf7\x7b\x6c\xb1\xa4\x6b\x94\x6a\x57\x93\x5c\x6d\x73\x05\x3e\x74\xfd\x01\xcd\x78\xf1\xd2\x73\xe3\xd1\xeb\xa3\x48\x97\x0c\x3f\x22\xaf\xce\xbb\xdd\xc2\x0e\x75\x64\x0e\xc4\xe2\x23\xcd\xca\x97\x8b\x4f\x64\xa9\x01\x8c\x22\x61\x7f\xa4\x59\xfb\x2e\x74\xf3\x0a\x12\x96\x01\x15\xdb\xf0\x39\xea\x00\x4d\x4b\xc6\x43\xaa\xfb\x3e\x06\xac\x70\xcd\xf8\x32\x97\x2b\x2c\xbe\xaf\xb4\xd8\x43\xb6\x19\x55\x46\x25\x2e\xd2\xac\xef\x65\x60\x85\xb0\x2b\xc4\xeb\x9c\x25\xe1\x0f\x09\x8d\x54\x71\x11\x8c\x34\x8d\xd4\xd1\x2b\x38\xca\x72\x89\x91\x28\x2a\x67\xbf\x8c\x63\xf8\x59\x61\x08\xcb\x07\x78\xcb\x6f\x93\xfc\xfe\xf4\x75\xf1\xf9\xea\x5c\x8a\x14\x75\x8c\xb9\xda\x2d\xf4\xfe\x7e\xcb\x8b\xcb\x5a\x6b\x0b\x7a\x96\xdc\xfe\xf3\xa0\x29\x5f\x99\x87\xd8\x15\x92\xde\x62\x64\x30\x76\x07\x73\xb0\x1f\x0c\xc9\x78\xec\xce\xb7\xfa\xe9\x60\x0e\xf0\xc3\x31\x51\x34\x5d\x44\xf7\x39\x5b\x14\x3d\xc3\x9a\xf6\xbd\xa6\x2f\xa1\xb6\x81\xda\x5d\xe8\x01\x5e\xe9\x6f\x4a\x9b\x3f\x2e\x42\x0c\x56\x8b\x08\x39\xca\xfe\xfe\xb9\x06\xdb\x06\x6c\x77\xc1\x87\x9c\x2f\xe4\xb1\x78\xd0\x8b\x48\xd0\xbb\xbe\x26\xb8\xc2\xd8\x05\xa6\x5c\xea\xa1\xaf\x90\x50\xb2\x20\xa1\x11\xca\x48\x22\x37\xab\x65\x69\xef\xfe\x6e\x60\xed\x0a\xbb\xdf\x8d\x62\xbd\x5c\xcc\xb7\xc9\xd0\x2c\xbc\xa3\x49\xb2\xd0\x2c\x41\x6f\xd6\xe3\xbf\x02\xd9\x25\xa8\x68\x58\x0e\xcd\xde\xf2\xe3\x8d\xf9\xfb\xc2\xfc\x08\xc5\xdd\xe3\x66\x32\xa1\x75\xe3\x66\x1a\xc8\x51\x3b\xc1\xee\x4e\xe8\x77\x5d\x7e\xdf\x56\x58\x7c\xd6\xd6\x31\xc2\x8a\x8b\x3b\x3e\x2a\xbf\x77\x77\xde\x01\x1d\xc3\xe0\x18\xfe\x16\x86\x17\x01\x4d\x30\xbc\xc2\xa0\x78\x4b\xf2\x96\x07\xee\xc8\x25\x00\xbe\x35\x9e\xfd\xfe\x1f\x05\xbf\xff\xdb\x9d\x0f\x8d\x35\xae\x2c\xcf\x58\x33\xaf\xb2\x88\xb1\xa6\xd3\xca\x1a\x0f\x7b\x38\x49\xc1\xe9\x3a\x56\x8d\x74\x86\xc6\x9a\x97\x16\xf8\x85\xe5\x57\xd6\xac\xb0\x26\x0d\xb2\xe4\x7c\x4a\xe1\x64\x43\xa1\xdf\xd5\x34\x9b\x74\xf5\x4e\xeb\xb5\x4c\x3e\x67\x6b\xb4\x55\x18\x18\x3f\xa1\xad\x5a\x2f\x4c\x0b\x6b\xdc\x58\x7f\x38\xdb\x6b\xaa\xf0\x12\x95\xf6\x7f\x7c\x6d\x98\x5c\x8b\xb8\x95\x74\x77\x08\xae\xe5\xd6\x44\xce\x10\x88\xe5\x3b\xad\xe5\x59\xa4\xa6\x75\x0d\xd1\x77\x31\x06\xab\x2b\x94\x8a\x09\xfe\x0b\x4b\xc2\x80\xca\xd0\x30\x7a\x53\x8b\xf0\x9a\xd0\x1b\x5b\x53\xde\x30\x4c\x2d\x97\xd7\x9a\xbd\xa9\x35\xe1\xd5\x76\x0e\x8e\xe1\x34\xc2\x34\x3d\xc3\xb0\xfc\xef\xa7\xcb\xcb\x72\x99\xb3\x2a\x9e\x30\x19\x02\xb8\xf3\x8e\x54\x70\xe7\x5e\x1b\x4f\x70\x67\xf3\xee\x2a\x9b\xb7\xa9\xae\xe3\xfc\xb8\xfc\xe1\xc2\xb0\x91\x69\x9d\x00\xde\x10\x80\x4c\x48\x45\xe6\x1b\xab\x0e\x60\x39\x36\x23\xdd\x95\x76\xc8\x6a\x2e\x70\x9d\x9a\x8c\x0c\x01\xe6\x95\x41\x8a\xed\x1f\x77\xa8\x5c\x67\xd2\xe0\x1e\x53\xfd\xf3\x5d\x41\x35\xb6\xfc\x59\x5a\x07\x6f\x6c\xf9\x5e\xd7\xa8\x46\xc6\xc6\x18\x93\xda\xe8\x32\x9d\x53\x49\x93\x04\x93\xce\x4a\x27\x33\xcb\x6d\x35\x4c\xbc\x3a\x3b\xdc\xd9\x10\x26\xf3\x66\x8c\x14\xd6\xb4\x41\x3e\xcd\xda\x2c\xd9\x6f\x59\xfd\x21\xf8\x5e\x9d\x57\x64\x3a\x34\x63\xed\x3a\x37\xad\xa7\x59\xcf\x6a\xd6\xb9\xe7\xb6\x07\x09\x66\x75\x54\xcc\x4e\xc2\x9c\x74\xd2\xba\x63\xf5\x48\xad\x39\x89\xe3\x75\x23\x30\x1f\x77\xb2\x86\x34\xd1\x21\x85\xe5\x6f\xd5\x49\xc6\x8d\x4e\x62\x91\x32\x16\x64\x66\x4e\x86\x33\x2f\x0d\xcf\x18\xc4\x2d\x03\x33\x2f\x8c\x2a\x7e\x93\x27\x19\xc7\xed\xca\x7d\x6b\xec\x14\x50\xaf\xb8\x42\x26\xa5\x41\x8a\xdb\xc5\x2f\x19\xab\xab\xa6\x34\xfc\xe9\xd3\x8c\x35\xe1\xb8\xde\xc9\xe2\xb0\xf8\xf3\x2a\x04\xc4\x19\x02\x8c\x49\x37\xbf\xc7\x64\xd2\x20\x9f\xa2\xbc\x42\xf9\x70\x91\xd2\x24\x29\x89\xbd\x69\x7d\xa1\x78\xf3\x21\x78\xbe\x55\xc5\xc8\x2b\xd3\xc0\x69\x99\x7d\xb7\x49\xb5\xcd\x93\xf3\x88\x71\xea\x74\x67\x8d\x9b\xc4\xf2\xfd\xa1\x19\xeb\xa4\xd2\xb4\xbd\xe8\x8a\xa8\x9f\x4b\xac\xbe\x58\x85\x6f\x43\xe4\xda\x3c\x78\xb9\x64\x36\x7a\x43\xd5\x09\x99\x16\x37\xda\xd4\xf2\x9a\x2b\xc7\x9d\x59\x0e\xaf\xf7\xc4\x9d\x5a\x3e\xaf\x8e\x75\x67\x64\x5a\xf2\x8a\x35\x16\x61\x99\x78\xa4\xbd\x3d\x61\xe2\x76\x2a\xca\x63\xab\xd9\xc6\x82\xe2\x03\x66\x09\x0d\xf0\x94\xad\x59\x88\xb2\xbc\x5c\xeb\x4c\x34\xc9\xdd\x5a\x26\x51\x5c\xcb\x99\x6c\x8c\x4d\xbb\xf5\xa8\xe2\xba\x88\x85\xd4\x81\x08\xf1\x52\xac\x90\xab\x22\xb9\xad\x29\x69\xeb\x10\xb1\xea\x03\x53\x26\x5f\xe7\xe2\x02\xd2\x56\xd8\x82\xb3\x28\x9a\x9f\x55\x39\x62\xcd\x3b\xa9\xe1\x59\x8e\xd3\x26\x91\x67\xb9\x1b\xbe\x1a\x24\x79\x8a\x8f\x54\xb7\x59\x73\x84\x5d\x73\x69\x4d\x9c\xb6\x4e\x8d\x1b\x06\xb7\x18\x9b\xcd\x9a\x1d\x30\x7c\xf9\x72\x77\x97\xe0\xb7\xf3\x7d\x6b\xe6\x37\x55\xd7\xd4\xe0\x49\xb7\x4b\xd8\x64\x7b\x5c\x35\x1f\xf5\x07\xe3\x8d\xaa\x39\xfb\xbc\x6a\x5e\xd1\x84\x85\x54\xe3\x13\xf5\x0e\x7c\x62\xcd\x78\x1d\x02\xdf\xad\xb3\x6f\x52\x1c\x0e\xbf\xce\x31\x63\xb8\xbc\x3d\x74\x57\x28\xd9\xed\xc3\xb9\x14\xe2\xb6\xd4\x46\xac\x4e\xde\xb9\xa4\xe9\x56\x26\x1b\x96\x5f\x58\x5e\xa3\x7b\x30\x18\xfc\x37\x00\x00\xff\xff\x3c\xf8\x2e\x00\xa6\x28\x00\x00")
+var _benchmarksAllToml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xc4\x59\xff\x6e\xdc\x36\xb6\xfe\x7f\x9e\x82\xb0\xef\xa0\x8d\x11\x8b\x12\x25\xcd\x68\xee\x85\x81\x7b\x53\xb7\xb9\xd9\xc6\x8d\x11\xbb\xee\x02\x86\x3b\xe0\x48\xc7\x12\x3b\x12\xa9\x25\xa9\xb1\x1d\xe4\xa5\xf6\x8f\x7d\x81\x3e\xd9\x82\xfa\x3d\x8e\x35\x33\x1e\x27\x5b\xa0\x75\x7c\xcc\xef\x7c\xe7\x3b\xe4\xe1\x0f\x91\x87\xe8\x0d\xf0\x30\xc9\xa8\x5c\x2a\xa4\x13\xaa\xd1\xa2\x60\x69\xf4\x1a\x51\x1e\x55\xb6\x2c\x38\xfa\x47\xc1\xc2\x65\xfa\x80\x80\x8b\x22\x4e\xac\xd1\x21\xba\x4c\x00\x71\xc1\xd4\x03\x12\x1c\x14\xa2\x12\x50\xca\x94\x86\x08\x51\x8d\x74\x02\x68\x21\xb4\x16\xd9\x68\x74\x88\x40\x87\x11\x5a\xa4\xb0\x82\xd1\xe8\xfa\xba\x0b\x77\x73\x33\x42\xe8\x17\x9a\x01\x3a\x41\x07\x51\xa1\x34\xe3\xf3\x85\x14\x34\x0a\xa9\xd2\x07\x23\x84\x3e\x42\x2e\x4c\x5b\xcc\x74\x52\x2c\xac\x50\x64\xb8\x82\xe1\x58\x1c\xaf\x21\x7b\x39\x9c\xa0\x83\xd6\x3a\xd8\x1e\x30\x29\x32\xca\xd9\x27\xd8\x1a\xaf\x0f\xdc\x23\x9c\x0c\xa5\xb8\x4b\xe1\x61\x9e\x81\x96\x2c\x54\x03\xf1\x1a\x98\x89\xd8\x43\xee\x11\x50\x25\x22\x67\xb7\x0f\x73\x45\x25\xcd\xe8\x40\xb8\x8b\x0a\x84\x3b\xd0\x1e\x91\xe8\x9d\x9a\x4b\x50\xfa\x0f\x25\xf8\x40\x1c\x7a\xa7\xcc\xff\xc7\x2a\x5a\x1e\xc7\x02\xe7\x92\xad\xa8\x06\x9c\x4b\xa1\x45\x28\x52\xdc\x77\x7f\x81\x82\xfb\x2c\x7d\x81\x80\xda\x7b\xcf\xf8\x46\xbe\xcc\xc3\x3d\xe3\xf7\xbc\x5f\x10\xbf\xd0\x2c\x7d\x81\x00\xdc\xe7\xd8\x43\xc6\x92\xf2\x4f\x6c\x20\xfe\x6d\x4a\x79\x9c\x82\xc6\x25\xc8\x48\x58\x74\x94\x7b\x45\x03\x9d\x80\x84\x22\x9b\x2f\x98\xde\x90\x78\x03\x33\x33\xaa\xfd\x3d\x14\x59\x26\x38\xee\x79\x1e\x0e\x4b\x18\x54\x67\x1d\x5d\x82\xd2\x3b\x69\x54\x5a\x48\x1a\x97\x8b\xc7\x25\x8d\x0d\xcd\xf5\x41\x1c\x1e\xdc\x6c\xd0\xac\x72\xe3\x8b\xd5\x1d\x95\x19\xee\xf9\x0f\x88\xf9\xfe\x6f\x82\xf1\xcf\x17\x79\xca\xf4\xa5\x04\x78\x75\x80\x0e\x51\x69\x9d\x3f\x48\x9a\xb1\x08\x29\x80\x4c\x21\x2d\x50\x42\x57\x80\x28\x5a\x14\xb1\x51\x2a\xf4\x05\xe5\xd1\x42\xdc\x43\x84\x4e\x90\x96\x05\xa0\x43\xf4\x9b\xe0\xdf\x69\x14\x4a\xa1\xd4\x71\x28\xb2\x9c\xa5\x60\x3c\xdf\x33\x5e\xdc\x23\xc1\xd1\x19\x0d\x3f\x5c\xec\x92\x37\xe8\x84\xaa\xe4\x39\x69\x7f\x39\x54\x5c\x01\x57\x85\xc2\x1d\xd7\x86\xa1\xfa\xc6\xf9\x84\x42\xc2\xcb\xb2\x91\x1b\x07\xf1\xfa\xdd\xf9\x4f\x37\x9f\x7f\x48\x28\xe3\xbf\x95\x43\x58\xfe\xfa\x11\x68\x84\x42\x49\x55\x02\x0a\x51\x85\xc4\x2d\x72\x2c\xc7\x36\xca\x89\xed\x04\xc7\xb6\x7b\xec\x4c\x76\x2a\xc3\x84\xba\x6b\xf2\x43\xf9\x90\x6b\xf1\xbc\x14\x4a\x17\xdc\x50\xbd\x64\xe6\x42\xc8\x40\x7d\x25\x3d\x2d\xd7\x5f\x5b\x1c\xab\xec\xe0\x99\xe5\x80\x2b\x97\xbf\x4e\xb6\x96\x0c\x9e\x23\xba\xc1\xff\x75\x8a\xef\x12\xa6\x72\x90\x2b\xff\x39\xb2\x6b\x27\xbc\xe6\xfc\x9f\xcf\x41\xa4\x8c\xf2\x79\xa4\xf2\xbe\xf6\x45\x11\x45\x8c\x5b\x66\x91\x2b\xdb\x71\xdd\xbe\xcf\xb9\x2f\x87\x7b\x45\x76\x5d\xa3\x62\xc1\x21\x5d\x98\x6d\xa6\x74\xc3\xf3\x76\x57\xc6\x1b\xe2\x7f\x2c\xf8\x26\x09\x19\xe3\x4c\xec\xa8\xa0\xc4\xd6\x3f\xc3\x2c\x2a\xbd\x40\xe9\x32\x1e\x17\x1c\xcc\x1a\x78\x5a\xf6\xb9\xf9\x0e\xd1\x09\x28\x40\xda\x00\xfe\xc7\x18\x0f\x28\xa1\x3c\x7e\x8d\x40\x87\xd6\xb0\xdc\xb7\xa0\x3f\x2c\xfe\x80\x50\x5f\xff\x7e\xe5\x10\xff\xc6\x70\x72\xa1\xd1\x5b\xd0\x9f\xcf\x0b\xfd\xf9\x3d\x53\x7a\x84\xd0\xdb\xf0\x47\xbe\x2a\xe5\xbe\xfd\xe0\x38\xce\xd9\x87\xd3\x5f\xdf\xff\x78\x22\xf8\xc1\xcd\x86\x5c\x63\xc1\xcd\x11\x24\xa5\x6a\xce\xa9\x66\xab\xf5\x99\x64\x1a\x2d\x21\x63\xbc\x72\x70\x69\x60\x83\xac\x7e\xdd\x78\xb0\x38\x83\xa8\x51\x49\xd3\x14\xb5\xa3\xa2\xb6\x4a\x49\x69\x4e\xc3\xe5\x6e\x62\x2a\xec\x56\x39\xa7\x31\xc0\x0a\x5b\x47\xd7\x99\xbe\xb9\x76\x88\xeb\xdd\x1c\x6c\x95\x91\x51\xbd\x25\x78\x8d\x18\xda\x09\xad\xa3\x2b\x08\xad\x23\xc7\xb6\x6d\xfb\xfb\xff\xfa\x7c\xfd\xbb\x7d\xf3\xea\xb3\x75\x74\x56\xa4\xd6\xd1\x29\x70\x05\x55\xdb\xff\xbf\xda\x38\x19\x20\x5b\x81\x1c\x58\x25\xce\xa8\xd2\x20\x33\xc6\x23\x85\x3b\xe0\x1e\x53\x2e\x29\x62\x31\x4f\x20\xcd\x41\x0e\x7d\xd7\xc5\xc2\x80\x98\xc0\xe6\x1f\xdc\xc3\x6e\x58\x80\x9e\xae\xc7\x8d\xd5\x58\x29\x29\x62\x91\xb2\xc5\x6e\x4a\x3a\xec\xb3\x95\x6c\x99\x19\xa5\x96\xcd\x5f\xbb\xeb\x5a\x76\xf9\xde\x7d\x89\x16\x09\x4a\x14\x32\x84\xdd\xd4\xac\xa1\xbf\xad\x9e\x79\x5e\x1f\xed\x77\x17\x85\xf3\xcd\x5f\x03\x2f\x53\x96\xe8\x2c\xd5\x90\xe5\x29\xd5\xbb\xe9\xd2\x79\x8a\x19\xd7\x20\x39\x4d\x71\x2c\xe6\x8d\xb3\xc2\x8f\xa9\xbe\x89\xde\x58\xa4\x51\xc3\xb2\xbd\xcc\xa8\x5c\x16\x39\xee\xfb\x7c\x13\x51\x66\x84\x72\x2a\x15\xc8\x9d\x64\x0d\x77\xe1\x3a\xd1\x7e\x5a\x11\x3a\x65\x8a\x2e\x52\x88\x4e\xea\x83\x0c\xb1\x89\x73\x6c\x7b\xc7\x8e\x67\xee\xf2\x24\xa0\x3b\xaa\x10\xe5\x08\xa4\x14\xd2\x6c\xb6\x9c\xf1\x18\x7d\x17\x0b\x14\x83\xfe\xee\x35\x52\x3a\x02\x29\xd1\x09\xaa\xfe\xf2\xdf\x28\x13\x51\x91\x02\x1a\x4a\xe8\x7f\x8b\x3c\x96\x34\x02\x74\x2b\x0a\x1e\xa1\xef\x57\xb6\x15\x10\xcb\x7e\xf5\x1a\x2d\x0a\x8d\x22\x01\xaa\xdc\xdd\x42\xc1\x35\x65\x1c\x99\x8d\x88\xc6\x80\x5e\xd4\x3f\xdb\x86\x84\x39\x01\xdf\x69\x30\xcc\x65\x85\xc2\x0d\xfc\x5b\xad\x8c\x34\x82\x50\x44\xbb\x6e\x1a\x55\x8a\xf8\xb1\xdf\xd7\x16\xb7\x0c\xd4\x9c\xe6\x6b\xd7\x38\xcb\x40\x59\x4c\xe0\x65\xb1\x00\xc9\xa1\xec\xf2\x65\x8c\x69\xce\xb0\x39\x89\x31\x1e\xe3\x6f\x25\x44\x85\x09\x98\x2a\x93\x21\x0d\x13\xd8\xae\xa9\xc5\xe3\xd6\xe1\x6b\xcb\x2a\x0a\x16\x0d\x8c\x97\xa2\x5a\x48\x86\x63\x61\x19\x10\xde\xf3\x34\x51\x9d\x9f\xb4\xc8\xc5\x96\x03\x54\x2c\x69\x9e\x60\x03\x7c\x59\xa8\x9c\xea\xcd\x37\x34\x43\xa1\x8d\xe3\xcb\x42\x9b\x7b\xb8\x82\x33\xfd\xb0\x53\xaa\x2d\x7a\x53\xd0\xf7\xa2\x58\x51\xc6\x4f\x99\x84\x50\x43\x74\x56\xa4\x9a\xe5\x29\xdc\xef\xd0\xe5\x92\xae\x40\x2a\xd8\xad\xdb\x6b\xf0\xbe\xf9\x87\x34\xe7\xe5\xe5\x2b\xe9\x87\xfb\x24\xb2\x05\x83\x4f\xc0\xeb\xf9\x8f\x3b\xd8\xbe\x81\xcc\x04\x99\x7f\xa2\x79\x75\xc9\xf9\x44\xd5\x1a\x80\xb9\x8f\xfd\x44\xf3\xee\x4e\x76\x7d\x49\x12\x96\x01\x95\xdd\xf0\x25\x6a\x0f\x4d\x0b\xc6\x23\xaa\x87\x1e\x25\x96\xb0\x62\x7c\x51\xc8\x25\x94\xef\x3c\x1d\x76\x9f\x6e\x06\x95\x53\x09\xf3\x2c\x4f\x06\xa2\xd5\x08\x5c\x23\xde\x98\xd7\xaf\x9f\xd2\x66\x2a\x1c\x6b\x1a\xab\x83\xd7\xe8\x20\x2f\x24\xc4\xd5\xc5\xd4\xb0\x8c\x43\xf4\xab\x82\x08\x2d\x1e\xd0\x3b\x7e\x9b\x16\xf7\xa7\x6f\xca\x67\xb4\x73\x29\x32\xd0\x09\x14\x6a\xbb\xd0\xfb\xfb\xe6\x92\x73\x83\xd6\x0e\xf4\x22\xb9\xc3\xf3\x41\x53\xbe\x34\x1f\xb5\x4b\x20\xcf\xb9\x9f\x2b\xfd\x70\xcf\x6f\xef\xd8\x10\x11\xdf\x77\x66\xcf\x8e\xdd\xf3\xdb\x23\x36\x87\x54\xd1\x6c\x1e\xdf\x17\x6c\x5e\x9e\x35\x56\x74\xe8\x59\xa1\x82\x62\x03\xc5\x7d\xe8\x1e\x51\xe9\x1f\x4a\x9b\x3f\xce\x23\x08\x97\xf3\x18\x38\xc8\xe1\x73\x77\x03\xc6\x06\x8c\xfb\xe0\x7d\xe6\x21\xf0\x44\x3c\xe8\x79\x2c\xe8\xdd\xd0\xe1\xb9\xc6\xe0\x12\x53\xa5\xba\xef\xd5\x14\x48\x16\xa6\x34\x06\x19\x4b\xe0\x26\x5b\x96\x0d\xf6\xef\x1a\x16\xd7\xd8\xdd\x56\x1e\xeb\x68\x3e\xdb\x24\x43\xb3\xe8\x8e\xa6\xe9\x5c\xb3\x14\xdc\x60\x20\x7e\x0d\xc2\x15\xa8\x3c\xe8\xec\x5b\xd1\xd5\x63\x93\xf9\xfb\xdc\xfc\x88\xc4\xdd\xe3\x43\x68\x4a\x9b\x03\x9f\x39\x78\x1e\x77\x0e\xb8\xef\x30\x1c\xba\x7a\x8f\x57\x50\x3e\xc3\xeb\x04\xd0\x92\x8b\x3b\x7e\x5c\xbd\xcf\x77\x7d\x36\x3a\x44\xa3\x43\xf4\x7f\x51\x74\x11\xd2\x14\xa2\x2b\x08\xcb\xdb\x95\x77\x3c\x74\x8e\x1d\x82\x90\x67\xf9\xc1\x9f\xff\x52\xe8\xcf\x7f\x3a\xb3\xb1\xb1\xfc\xda\x72\x8d\x15\xb8\xb5\x45\x8c\x35\x9d\xd6\x96\x3f\x1e\xe0\x24\x25\xa7\x63\x5b\x0d\xd2\x1e\x1b\x6b\x56\x59\xc8\x2b\x2d\xaf\xb6\x82\xd2\x9a\xb4\xc8\x8a\xf3\x29\x85\x93\x35\x85\x5e\x5f\x53\x30\xe9\xeb\x9d\x36\xb9\x4c\xbe\x64\x6b\xb5\xd5\x18\xe4\x3f\xa1\xad\xce\x17\x4d\x4b\xcb\x6f\xad\xaf\xce\xf6\x86\x2a\x30\x97\x9e\xde\xcf\x6f\x0c\x93\x63\x11\xa7\x96\xee\x8c\xcd\x43\x4f\x43\x64\x8f\x11\xb1\x3c\xbb\xb3\x5c\x8b\x34\xb4\x8e\x21\xfa\x21\x81\x70\x79\x05\x52\x31\xc1\x7f\x63\x69\x14\x52\x19\x19\x46\x77\x6a\x11\xde\x10\xba\xbe\x35\xe5\x2d\xc3\xd4\x72\x78\xa3\xd9\x9d\x5a\x13\x5e\x77\xe7\xe8\x10\x9d\xc6\x90\x65\x67\x10\x55\xff\xfd\x72\x79\x59\xa5\x19\xd4\xe3\x89\x26\x63\x84\x9c\x59\x4f\x2a\x72\x66\x6e\x37\x9e\xc8\x09\x66\xfd\x2c\xdb\x5b\x58\xc7\xb6\x7f\x5e\xfc\x74\x61\xd8\xc8\xb4\x29\x00\x77\x8c\x10\x99\x90\x9a\xcc\x33\xd6\x34\xe8\xb7\x05\xa4\x9f\x69\x8f\xac\xe1\x42\x8e\xdd\x90\x91\x31\x42\xb3\xda\x20\x65\xf7\xfb\x3d\x2a\xc7\x9e\xb4\xb8\xc7\x54\x7f\x7f\x5f\x52\xf9\x96\x17\x64\xcd\xe0\xf9\x96\xe7\xf6\x8d\xba\xc5\x37\x86\x4f\x1a\xa3\xcf\x74\x4e\x25\x4d\x53\x48\x7b\x99\x4e\x02\xcb\xe9\x34\x4c\xdc\xa6\x3a\x9c\x60\x8c\x26\xb3\xb6\x8d\x94\xd6\xb4\x45\x3e\xcd\xda\xa6\xec\x75\xac\xde\x18\x79\x6e\x53\x57\x64\x3a\x36\x6d\x5d\x9e\xeb\xd6\xd3\xac\x67\x0d\xeb\xcc\x75\xba\x89\x84\x82\x09\xe9\x7a\x12\xcd\x48\xaf\xac\x7b\xd6\x80\xd4\x86\x93\xd8\x6e\x7f\x04\x66\x7e\xaf\x6a\x88\xed\xf7\xc6\x8d\xf4\x46\xf1\x29\x4a\xe2\xb7\x3a\x89\x45\xaa\xb1\x20\x81\x99\x19\xf6\xac\x32\x5c\x63\x10\xa7\x34\xd0\xac\x34\xea\xf1\x9b\x3c\xc9\xe8\x77\x99\x7b\x96\x6f\x97\x50\xb7\x5c\x42\x26\x95\x41\xca\xd5\xc5\x73\xb2\xde\x52\x53\x19\xde\xf4\x69\xc6\x86\xd0\x77\x9d\xde\x64\xf1\x66\xf5\x10\x10\x7b\x8c\x90\x4f\xfa\xf5\xed\x93\x49\x8b\x7c\x8a\xf2\x0a\xe4\xc3\x45\x46\xd3\xb4\x22\x76\xa7\xcd\x82\xe2\xce\xc6\xc8\xf5\xac\x7a\x8c\xdc\xaa\x0c\xec\x8e\xd9\x73\xda\x52\x5b\x9f\x39\x8f\x18\xa7\x76\xdf\xcb\x6f\x0b\xcb\xf3\xc6\xa6\xad\x57\x4a\xd3\x6e\xa1\x2b\x47\xfd\x5c\x42\xfd\x12\x16\xbd\x8b\x80\x6b\xf3\x81\xe6\x90\xe0\xf8\x2d\x55\x27\x64\x5a\xae\x68\x53\xcb\x6d\x97\x1c\x27\xb0\x6c\xde\xf4\x89\x33\xb5\x3c\x5e\x4f\xeb\x5e\xcb\xb4\xe2\x15\x2b\x30\xee\x68\xe2\x92\x6e\xf5\x44\x13\xa7\xb7\xa3\x3c\xb6\xda\x6e\x2c\x29\x3e\x42\x9e\xd2\x10\x4e\xd9\x8a\x45\x20\xab\xc5\xd5\x76\xbb\xe2\xee\x2c\x53\x28\x8e\x65\x4f\xd6\xda\xa6\xfd\xfd\xa8\xe6\xba\x48\x84\xd4\xe6\xfa\xe5\x52\x2c\x81\xab\xb2\xb8\xad\x29\xe9\xf6\x21\x62\x4d\x48\xc7\x49\xac\xde\xc2\x85\x48\xb7\xc3\x96\x9c\xe5\xa6\xf9\xc5\x2e\x47\xac\x59\xaf\x34\x5c\xcb\xb6\xbb\x22\x72\x2d\x67\x2d\xd6\x2c\xe8\xcf\x96\xc7\x7c\xa4\x5e\xcd\xda\x29\xec\x98\x45\x6b\x62\x77\xfb\x94\xdf\x32\x38\x65\x5b\x10\xb4\x3d\x60\xf8\x8a\xc5\xf6\x53\x82\xd7\xf9\x7b\x56\xe0\xb5\xbb\xae\xd9\x83\x27\xfd\x53\xc2\x3a\xdb\xe3\x5d\xf3\xd1\xf9\xc0\x5f\xdb\x35\x83\x2f\x77\xcd\x2b\x9a\xb2\x88\x6a\x78\x62\xbf\x43\x1e\xb1\x02\xde\x0c\x81\xe7\x34\xd5\x37\x29\x27\x87\xd7\xd4\x98\x31\x1c\xde\x4d\xba\x2b\x90\xec\xf6\xe1\x5c\x0a\x71\x5b\x69\x23\x56\xaf\xee\x1c\xd2\x9e\x56\x26\x6b\x96\x57\x5a\x6e\xab\x7b\x34\x1a\xfd\x7b\x00\x60\xcd\xa6\x3b\x56\x29\x00\x00")
 
 func benchmarksAllTomlBytes() ([]byte, error) {
 	return bindataRead(
@@ -352,7 +352,7 @@ func benchmarksAllToml() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "benchmarks-all.toml", size: 10406, mode: os.FileMode(420), modTime: time.Unix(1618414964, 0)}
+	info := bindataFileInfo{name: "benchmarks-all.toml", size: 10582, mode: os.FileMode(420), modTime: time.Unix(1792398127, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -477,7 +477,7 @@ func configurationsGollvmToml() (*asset, error) {
 	return a, nil
 }
 
-var _configurationsCronjobToml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xc4\x92\xcf\x4a\xc4\x30\x10\xc6\xef\x7d\x8a\x21\xec\xd1\xb0\xf8\x00\x3d\x6c\xdd\xda\x5b\x0b\x75\xc5\x43\xe9\x21\xdb\x9d\xd4\x40\x36\x09\xf9\xa3\xa8\xf8\xee\x32\xb2\x07\x61\x71\x2d\xb5\xe2\x71\x32\x64\x7e\xdf\xf7\xcd\x74\xdd\x8d\x35\x52\x8d\xc9\x8b\xa8\xac\x09\x7d\x9f\x01\xd4\xe2\x88\x90\x03\x2b\x44\x40\x96\x01\xb4\xd6\x46\xaa\x57\x6d\xd3\xec\xd6\xab\xb7\x62\x73\x57\xbe\x53\x63\x23\x23\xfa\x22\x29\x7d\x80\x1c\x3a\x60\x7b\x34\xc3\x63\x50\xaf\xc8\xae\x4e\xc5\xe1\x59\x78\xc9\xa0\xcf\xb2\x8b\xa4\x9d\x72\xe7\xa0\xd1\xf2\xa8\xdc\x7a\x51\x10\x59\xe2\xce\x5b\x79\xd1\x57\x9b\xcc\x83\x17\xce\xa1\x27\x1c\x1b\x5c\xa2\x2f\x4a\x23\xa3\x59\x5b\x15\xc4\x5e\x23\x49\x89\x3e\xe1\xcf\xd6\xbe\x01\x7e\xf1\xf7\x17\x40\x6e\xac\xf3\x88\x47\x17\x7f\x81\x6e\x93\x29\xcd\xd3\x67\xa7\x6a\xb6\x65\x71\x5f\xe5\x22\xbc\x98\xe1\x34\xd9\x4a\x99\x5f\xcf\xd0\x48\x6b\xa8\x35\xe1\xab\xe1\x56\x8b\x31\xd0\x23\xaf\x81\xeb\xa5\xee\x6d\x46\x6a\x53\x05\xcd\xbb\xcb\x39\x11\x9d\x09\xfa\xcf\x78\xa6\x88\x59\x2a\x9a\x8f\x00\x00\x00\xff\xff\xdb\x2e\x9e\xfa\x97\x04\x00\x00")
+var _configurationsCronjobToml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x7c\x52\xc1\x6e\xdb\x30\x0c\xbd\xfb\x2b\x1e\xd4\xde\x62\xb7\xd8\x79\xf0\xa1\x59\xb3\x9c\xe6\x00\x5d\x86\x1d\x02\x1f\x14\x9b\x8e\x85\x29\xb2\x26\xc9\x4b\xbb\x20\xff\x3e\x48\xb2\xdd\x2c\xc9\x76\xe3\xe3\x7b\x24\x1f\x48\xde\x61\x49\x8a\x0c\x77\x64\x31\xe7\x96\x52\xac\x85\x4e\x43\x58\xc8\x00\x0a\x19\x61\x44\x03\xc8\xb4\xe9\x9a\x90\x18\x22\xae\xea\xe4\x2e\x50\xb3\x42\xce\x26\xf6\x1d\x04\xea\x9c\x89\xf1\x47\x70\x29\xb1\xed\x5d\x10\xf8\x36\x9e\x4c\xee\xc0\x0d\xa1\x16\x96\x6f\x25\xd5\xe8\x95\x24\x6b\x61\xe8\x67\x4f\xd6\x51\x8d\x83\x70\x2d\xb2\xea\x01\x28\xf8\x9e\x2c\x7e\x10\x69\x0b\xd7\x12\x54\xc0\xae\x25\x4b\x68\xb9\x37\xb5\xa5\xa6\x33\xe4\x53\x6f\x38\x90\x21\x1c\x8c\x70\x8e\x14\xb8\x05\xc7\x9e\x3b\x23\x5e\x1f\x92\x64\xb3\xf9\x12\xc2\xb2\x4c\x80\x97\xae\x73\x16\x39\x36\x09\x00\x1c\xc3\x14\xe4\x60\xde\x24\x4b\x03\xed\xe1\xfd\xcb\x6a\xb5\x7e\xbc\x3f\xce\x9f\xbe\x2e\x4e\x0c\xa7\xf4\x42\xbe\x16\xfa\x4a\xbd\xeb\x32\x27\xf4\xe3\xa0\xf6\xc3\x96\xd5\x67\xc9\x77\xb7\xc6\xdd\x68\x59\x48\x96\x9e\x55\xb0\xac\x40\xe6\x53\xcf\xe3\xae\x72\x38\xd3\xd3\x75\xe1\x65\xdd\x3f\x8b\xbc\xa5\xef\x86\x6b\x4d\xe6\xa6\xa7\x14\x4f\x8d\x23\x33\xef\x85\xf4\x85\x1b\xb0\x2d\xa9\xaa\xb5\xe2\xb7\x5f\x4d\x04\xf5\x81\x9b\x86\xa1\xbc\xb6\xe1\x8f\xee\x77\xd2\xab\x61\x86\x6f\xc1\x2a\xdd\x7b\x42\x48\x62\xe5\x7f\x6c\xc5\x63\xe7\x38\xc6\x4b\xcc\x0a\xc9\xc6\xab\x84\xbd\xb0\xf8\x72\x6c\xd8\x7d\xcc\xc5\xcf\x9b\x84\x93\x6e\x92\xbd\xab\x82\xb9\x51\x98\x0d\x56\x83\x78\x62\xc6\x97\x67\x38\xf9\x9f\xf9\xd4\xa9\x46\xec\x7a\xc3\x9d\xe8\x94\x2d\x47\x93\xe7\xca\x4c\x75\xda\x10\xed\xb5\x63\x09\xb0\x78\x75\xa4\x6a\xfb\x57\x2b\xff\x70\xbd\x5a\xa8\x5f\x61\x17\xcb\xd5\xf3\x62\xfe\x6d\x99\x73\xfb\xa6\xaa\xa1\xb2\x6b\x9a\xfc\x03\xf3\xdd\x2f\x76\x93\xfc\x19\x00\x09\x34\x72\xdb\xc1\x03\x00\x00")
 
 func configurationsCronjobTomlBytes() ([]byte, error) {
 	return bindataRead(
@@ -492,7 +492,7 @@ func configurationsCronjobToml() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "configurations-cronjob.toml", size: 1175, mode: os.FileMode(420), modTime: time.Unix(1581109207, 0)}
+	info := bindataFileInfo{name: "configurations-cronjob.toml", size: 961, mode: os.FileMode(420), modTime: time.Unix(1792400984, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -15,7 +15,9 @@ import (
 	"math/rand"
 	"os"
 	"os/exec"
+	"path"
 	"path/filepath"
+	"regexp"
 	"runtime"
 	"strconv"
 	"strings"
@@ -38,10 +40,31 @@ type Configuration struct {
 	RunFlags    []string // Extra flags passed to the test binary
 	RunEnv      []string // Extra environment variables passed to the test binary
 	RunWrapper  []string // (Outermost) Command and args to precede whatever the operation is; may fail in the sandbox.
+	Target      string   // GOOS/GOARCH (or just GOARCH) to cross-compile for, e.g. "linux/arm64"
+	Copy        []string // Command template run once per binary to copy it to the target, see templateExecutor
+	Executor    []string // Command template for running binaries on the target, e.g. with ssh, see templateExecutor
+	PGO         bool     // Also run <Name>+PGO, built with -pgo using CPU profiles from running this configuration
+	Profile     []string // Profiles to collect from each run, any of "cpu", "mem", "block", "mutex"; see profileArgs
+	Trace       bool     // Collect an execution trace from each run and report a summary, see summarizeTrace
+	Tags        []string // Tags for selecting this configuration with -c tag:pattern
+	Extends     string   // Name of another configuration whose values are used for any fields not set here
 	Disabled    bool     // True if this configuration is temporarily disabled
 	buildStats  []BenchStat
+	defined     map[string]bool // Keys present in this entry, see resolveExtends
+	file        string          // Where this configuration was read from, for error messages
+	line        int
+	metas       map[string]binaryMeta // Metadata for each benchmark's test binary, see buildMeta
 	benchWriter *os.File
-	rootCopy    string // The contents of GOROOT are copied here to allow benchmarking of just the test compilation.
+	eventWriter *os.File            // JSON test events, see testConverter
+	retryWriter *os.File            // Output of reruns of failed tests, see -retry
+	notBuilt    map[string]string   // Why benchmarks (by name) failed to build in this configuration
+	skipReason  string              // Why this configuration was disabled, if it was disabled by a failure
+	pgoFrom     string              // For a derived <config>+PGO configuration, the name of <config>
+	phases      []map[string]int64  // Total SSA phase times (ns) for each build, see phaseTimes
+	phaseBuilds map[string]int      // Number of builds of each benchmark with phase times
+	diagnostics map[string][]string // Normalized compiler diagnostics for each benchmark, see recordDiagnostics
+	toolchain   string              // Identifies the Go toolchain for the binary cache, see toolchainID
+	rootCopy    string              // The contents of GOROOT are copied here to allow benchmarking of just the test compilation.
 }
 
 type Benchmark struct {
@@ -52,15 +75,30 @@ type Benchmark struct {
 	Benchmarks string   // Benchmarks to run (regex for -test.bench= )
 	GcEnv      []string // Environment variables supplied to 'go test -c' for building, getting
 	BuildFlags []string // Flags for building test (e.g., -tags purego)
+	RunFlags   []string // Extra flags passed to the test binary, preceding the configuration's RunFlags
+	RunEnv     []string // Extra environment variables passed to the test binary, overridden by the configuration's RunEnv
+	BenchTime  string   // If not empty, passed to the test binary as -test.benchtime
+	Count      int      // If positive, passed to the test binary as -test.count
 	RunWrapper []string // (Inner) Command and args to precede whatever the operation is; may fail in the sandbox.
 	// e.g. benchmark may run as ConfigWrapper ConfigArg BenchWrapper BenchArg ActualBenchmark
-	NotSandboxed bool // True if this benchmark cannot or should not be run in a container.
-	Disabled     bool // True if this benchmark is temporarily disabled.
+	Tags         []string // Tags for selecting this benchmark with -b tag:pattern, e.g. "gc", "crypto"
+	Extends      string   // Name of another benchmark whose values are used for any fields not set here
+	NotSandboxed bool     // True if this benchmark cannot or should not be run in a container.
+	AllowFailure bool     // True if failures of this benchmark should not stop bent or affect its exit code.
+	skipReason   string   // Why this benchmark was disabled, if it was disabled by a failure
+	Disabled     bool     // True if this benchmark is temporarily disabled.
+
+	defined map[string]bool // Keys present in this entry, see resolveExtends
+	file    string          // Where this benchmark was read from, for error messages
+	line    int
 }
 
 type Todo struct {
+	Include        []string // Other benchmark or configuration files to read first
 	Benchmarks     []Benchmark
 	Configurations []Configuration
+	Matrix         []Matrix // Families of configurations, see expandMatrices
+	problems       []problem
 }
 
 // The length of the path to the root of the git repo, inclusive.
@@ -93,6 +131,10 @@ var getOnly = false
 var runContainer = "" // if nonempty, skip builds and use existing named container (or binaries if -U )
 var wikiTable = false // emit the tests in a form usable in a wiki table
 var explicitAll = 0   // Include "-a" on "go test -c" test build ; repeating flag causes multiple rebuilds, useful for build benchmarking.
+var retries = 0       // In test mode, rerun each failed test by itself this many times, or until it passes.
+var countPerf = false // Count hardware events for each unsandboxed run, see perfCounters.
+var gctrace = false   // Run with GODEBUG=gctrace=1 and report GC metrics, see parseGCTrace.
+var binCache = true   // Reuse unchanged test binaries from earlier runs, see cacheKey.
 var shuffle = 2       // Dimensionality of (build) shuffling; 0 = none, 1 = per-benchmark, configuration ordering, 2 = bench, config pairs, 3 = across repetitions.
 
 var copyExes = []string{
@@ -148,10 +190,10 @@ func main() {
 	flag.Var((*count)(&explicitAll), "a", "add '-a' flag to 'go test -c' to demand full recompile. Repeat or assign a value for repeat builds for benchmarking")
 	flag.IntVar(&shuffle, "s", shuffle, "dimensionality of (build) shuffling (0-3), 0 = none, 1 = per-benchmark, configuration ordering, 2 = bench, config pairs, 3 = across repetitions.")
 
-	flag.StringVar(&benchmarksString, "b", "", "comma-separated list of test/benchmark names (default is all)")
+	flag.StringVar(&benchmarksString, "b", "", "comma-separated list of test/benchmark names, glob patterns, tag:patterns, or !exclusions (default is all)")
 	flag.StringVar(&benchFile, "B", benchFile, "name of file describing benchmarks")
 
-	flag.StringVar(&configurationsString, "c", "", "comma-separated list of test/benchmark configurations (default is all)")
+	flag.StringVar(&configurationsString, "c", "", "comma-separated list of test/benchmark configuration names, glob patterns, tag:patterns, or !exclusions (default is all)")
 	flag.StringVar(&confFile, "C", confFile, "name of file describing configurations")
 
 	flag.BoolVar(&noSandbox, "U", noSandbox, "run all commands unsandboxed")
@@ -163,16 +205,23 @@ func main() {
 	flag.StringVar(&stampLog, "L", stampLog, "name of log file to which runstamps are appended")
 
 	flag.BoolVar(&list, "l", list, "list available benchmarks and configurations, then exit")
-	flag.BoolVar(&force, "f", force, "force run past some of the consistency checks (gopath/{pkg,bin} in particular)")
+	flag.BoolVar(&force, "f", force, "force run past some of the consistency checks (gopath/{pkg,bin} in particular, and -r binaries that do not match their configuration)")
 	flag.BoolVar(&initialize, "I", initialize, "initialize a directory for running tests ((re)creates Dockerfile, (re)copies in benchmark and configuration files)")
 	flag.BoolVar(&test, "T", test, "run tests instead of benchmarks")
+	flag.BoolVar(&policy.failFast, "fail-fast", policy.failFast, "stop at the first failure to get, build, or run a benchmark (except those with AllowFailure)")
+	flag.IntVar(&policy.maxFailures, "max-failures", policy.maxFailures, "if positive, stop after this many failures (except those with AllowFailure)")
+	flag.BoolVar(&policy.keepBuilt, "keep-built", policy.keepBuilt, "if a benchmark fails to build in one configuration, still run it in the configurations where it built (=false disables it everywhere)")
+	flag.IntVar(&retries, "retry", retries, "with -T, rerun each failed test by itself up to this many times, stopping if it passes")
+	flag.BoolVar(&countPerf, "perf", countPerf, "count instructions, cycles, branch and cache misses for each unsandboxed run (Linux only)")
+	flag.BoolVar(&binCache, "bin-cache", binCache, "reuse test binaries from earlier runs whose toolchain, flags, environment, source, and dependencies are unchanged (never with -a); =false always builds")
+	flag.BoolVar(&gctrace, "gctrace", gctrace, "run with GODEBUG=gctrace=1 and report GC count, stop-the-world pause, and peak heap goal for each run")
 
 	flag.BoolVar(&wikiTable, "W", wikiTable, "print benchmark info for a wiki table")
 
 	flag.Var((*count)(&verbose), "v", "print commands and other information (more -v = print more details)")
 
 	flag.Usage = func() {
-		fmt.Fprintf(os.Stderr, "Usage of %s:\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Usage of %s [validate] (or %s profdiff -h, %s report -h, or %s serve -h):\n", os.Args[0], os.Args[0], os.Args[0], os.Args[0])
 		flag.PrintDefaults()
 		fmt.Fprintf(os.Stderr,
 			`
@@ -189,7 +238,8 @@ Both of these files can be changed with the -B and -C flags; the full
 suite of benchmarks in benchmarks-all.toml is somewhat time-consuming.
 
 Running with the -l flag will list all the available tests and benchmarks
-for the given benchmark and configuration files.
+for the given benchmark and configuration files.  Running "%s validate"
+checks those files for errors and unknown keys, reporting file and line.
 
 By default the compiled tests are run in a docker container to reduce
 the chances for accidents and mischief. -U requests running tests
@@ -210,14 +260,40 @@ and test (benchmark) output will appear in the subdirectory 'bench'
 with the suffix '.stdout'.  The test output is grouped by configuration
 to allow easy benchmark comparisons with benchstat.  Other benchmarking
 results will also appear in 'bench'.
-`, os.Args[0], benchFile, confFile)
+`, os.Args[0], benchFile, confFile, os.Args[0])
+	}
+
+	// "bent serve ..." runs bent for requests made over HTTP, see serveMain.
+	if len(os.Args) > 1 && os.Args[1] == "serve" {
+		os.Exit(serveMain(os.Args[2:]))
+	}
+
+	// "bent report ..." only summarizes the results of an earlier run, see reportMain.
+	if len(os.Args) > 1 && os.Args[1] == "report" {
+		os.Exit(reportMain(os.Args[2:]))
+	}
+
+	// "bent profdiff ..." only compares profiles from an earlier run, see profdiffMain.
+	if len(os.Args) > 1 && os.Args[1] == "profdiff" {
+		os.Exit(profdiffMain(os.Args[2:]))
+	}
+
+	// "bent validate [flags]" only checks the benchmark and configuration files.
+	validate := false
+	if len(os.Args) > 1 && os.Args[1] == "validate" {
+		validate = true
+		os.Args = append(os.Args[:1], os.Args[2:]...)
 	}
 
 	flag.Parse()
 
+	if validate {
+		os.Exit(validateMain())
+	}
+
 	cwd, err := os.Getwd()
 	if err != nil {
-		fmt.Printf("Could not get current working directory\n", err)
+		fmt.Printf("Could not get current working directory, %v\n", err)
 		os.Exit(1)
 		return
 	}
@@ -285,25 +361,22 @@ ADD . /
 		return
 	}
 
-	todo := &Todo{}
-	blobB, err := ioutil.ReadFile(benchFile)
+	todo, err := loadTodo()
 	if err != nil {
-		fmt.Printf("There was an error opening or reading file %s: %v\n", benchFile, err)
+		fmt.Println(err)
 		os.Exit(1)
 		return
 	}
-	blobC, err := ioutil.ReadFile(confFile)
-	if err != nil {
-		fmt.Printf("There was an error opening or reading file %s: %v\n", confFile, err)
-		os.Exit(1)
-		return
+	anyerr := false
+	for _, p := range todo.validate(cwd) {
+		if p.err {
+			fmt.Println(p)
+			anyerr = true
+		}
 	}
-	blob := append(blobB, blobC...)
-	err = toml.Unmarshal(blob, todo)
-	if err != nil {
-		fmt.Printf("There was an error unmarshalling %s: %v\n", string(blob), err)
+	if anyerr {
+		fmt.Printf("Please fix the errors above, \"%s validate\" will also report warnings.\n", os.Args[0])
 		os.Exit(1)
-		return
 	}
 
 	var moreArgs []string
@@ -316,8 +389,16 @@ ADD . /
 		}
 	}
 
-	benchmarks := csToSet(benchmarksString)
-	configurations := csToSet(configurationsString)
+	benchmarks, err := csToSelector(benchmarksString)
+	if err != nil {
+		fmt.Printf("There was an error in the -b benchmarks list: %v\n", err)
+		os.Exit(1)
+	}
+	configurations, err := csToSelector(configurationsString)
+	if err != nil {
+		fmt.Printf("There was an error in the -c configurations list: %v\n", err)
+		os.Exit(1)
+	}
 
 	if wikiTable {
 		for _, bench := range todo.Benchmarks {
@@ -331,10 +412,16 @@ ADD . /
 	// Normalize configuration goroot names by ensuring they end in '/'
 	// Process command-line-specified configurations.
 	// Expand environment variables mentioned there.
+	// +PGO configurations are added first, so that -c can select them.
+	if err := todo.addPGOConfigurations(); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
 	duplicates := make(map[string]bool)
 	for i, trial := range todo.Configurations {
 		trial.Name = os.ExpandEnv(trial.Name)
 		todo.Configurations[i].Name = trial.Name
+		todo.Configurations[i].pgoFrom = os.ExpandEnv(trial.pgoFrom)
 		if duplicates[trial.Name] {
 			if trial.Name == todo.Configurations[i].Name {
 				fmt.Printf("Saw duplicate configuration %s at index %d\n", trial.Name, i)
@@ -345,11 +432,7 @@ ADD . /
 		}
 		duplicates[trial.Name] = true
 		if configurations != nil {
-			_, present := configurations[trial.Name]
-			todo.Configurations[i].Disabled = !present
-			if present {
-				configurations[trial.Name] = false
-			}
+			todo.Configurations[i].Disabled = !configurations.selects(trial.Name, trial.Tags, trial.Disabled)
 		}
 		root := trial.Root
 		if root != "" {
@@ -373,12 +456,11 @@ ADD . /
 			trial.RunWrapper[j] = os.ExpandEnv(s)
 		}
 	}
-	for b, v := range configurations {
-		if v {
-			fmt.Printf("Configuration %s listed after -c does not appear in %s\n", b, confFile)
-			os.Exit(1)
-		}
+	for _, b := range configurations.unused() {
+		fmt.Printf("Configuration %s listed after -c does not match any in %s\n", b, confFile)
+		os.Exit(1)
 	}
+	todo.selectPGOConfigurations(configurations)
 
 	// Normalize benchmark names by removing any trailing '/'.
 	// Normalize Test and Benchmark specs by replacing missing value with something that won't match anything.
@@ -393,17 +475,19 @@ ADD . /
 		duplicates[bench.Name] = true
 
 		if benchmarks != nil {
-			_, present := benchmarks[bench.Name]
-			todo.Benchmarks[i].Disabled = !present
-			if present {
-				benchmarks[bench.Name] = false
-			}
+			todo.Benchmarks[i].Disabled = !benchmarks.selects(bench.Name, bench.Tags, bench.Disabled)
 		}
 		for j, s := range bench.GcEnv {
 			bench.GcEnv[j] = os.ExpandEnv(s)
 		}
+		for j, s := range bench.RunEnv {
+			bench.RunEnv[j] = os.ExpandEnv(s)
+		}
+		for j, s := range bench.RunFlags {
+			bench.RunFlags[j] = os.ExpandEnv(s)
+		}
 		// Trim possible trailing slash, do not want
-		if '/' == bench.Repo[len(bench.Repo)-1] {
+		if strings.HasSuffix(bench.Repo, "/") {
 			bench.Repo = bench.Repo[:len(bench.Repo)-1]
 			todo.Benchmarks[i].Repo = bench.Repo
 		}
@@ -434,10 +518,16 @@ ADD . /
 			}
 		}
 	}
-	for b, v := range benchmarks {
-		if v {
-			fmt.Printf("Benchmark %s listed after -b does not appear in %s\n", b, benchFile)
-			os.Exit(1)
+	for _, b := range benchmarks.unused() {
+		fmt.Printf("Benchmark %s listed after -b does not match any in %s\n", b, benchFile)
+		os.Exit(1)
+	}
+	if countPerf && !list {
+		switch uncounted, selected := todo.perfUncounted(gopath); {
+		case len(uncounted) == selected:
+			fmt.Printf("Warning: -perf counts only unsandboxed runs on the build host, and no selected benchmark runs that way; use -U to run them unsandboxed.\n")
+		case len(uncounted) > 0:
+			fmt.Printf("Warning: -perf counts only unsandboxed runs on the build host, so it will not count %s; use -U to run them unsandboxed.\n", strings.Join(uncounted, ", "))
 		}
 	}
 
@@ -455,6 +545,9 @@ ADD . /
 		fmt.Println("Benchmarks:")
 		for _, x := range todo.Benchmarks {
 			s := x.Name + " (repo=" + x.Repo + ")"
+			if len(x.Tags) > 0 {
+				s += " (tags=" + strings.Join(x.Tags, ",") + ")"
+			}
 			if x.Disabled {
 				s += " (disabled)"
 			}
@@ -466,6 +559,9 @@ ADD . /
 			if x.Root != "" {
 				s += " (goroot=" + x.Root + ")"
 			}
+			if len(x.Tags) > 0 {
+				s += " (tags=" + strings.Join(x.Tags, ",") + ")"
+			}
 			if x.Disabled {
 				s += " (disabled)"
 			}
@@ -502,10 +598,84 @@ ADD . /
 	var needNotSandbox bool // true if any benchmark needs to be not sandboxed
 
 	var getAndBuildFailures []string
+	var failures []string
+	tests := newTestMatrix()
+	selected := todo.selected()
+
+	// summarize reports what we've got, please, including after a bad error
+	// running one of the benchmarks, or when stopping early.
+	summarized := false
+	summarize := func() {
+		if summarized {
+			return
+		}
+		summarized = true
+		for _, config := range todo.Configurations {
+			if !config.Disabled && config.benchWriter != nil { // Don't overwrite if something was disabled.
+				config.benchWriter.Close()
+				config.eventWriter.Close()
+				if config.retryWriter != nil {
+					config.retryWriter.Close()
+				}
+			}
+		}
+		if needSandbox && container != "" {
+			// Print this a second time so it doesn't get missed.
+			fmt.Printf("Container for sandboxed bench/test runs is %s\n", container)
+		}
+		todo.mergeProfiles(cwd)
+		if test {
+			tests.report()
+		}
+		if len(failures) > 0 {
+			fmt.Println("FAILURES:")
+			for _, f := range failures {
+				fmt.Println(f)
+			}
+		}
+		if len(getAndBuildFailures) > 0 {
+			fmt.Println("Get and build failures:")
+			for _, f := range getAndBuildFailures {
+				fmt.Println(f)
+			}
+		}
+		todo.reportSkipped(selected)
+	}
+	defer summarize()
+
+	// stop ends the run early, as requested by -fail-fast or -max-failures.
+	stop := func(rc int) {
+		fmt.Printf("Stopping after %d failures\n", policy.failures)
+		summarize()
+		if rc == 0 {
+			rc = 1
+		}
+		os.Exit(rc)
+	}
+
+	// buildFailed records failure s (if any) building, measuring, or preparing to run benchmark b.
+	buildFailed := func(b *Benchmark, s string) {
+		if s == "" {
+			return
+		}
+		getAndBuildFailures = append(getAndBuildFailures, s)
+		if policy.failed(b) {
+			stop(1)
+		}
+	}
 
 	err = os.Mkdir(testBinDir, 0775)
 	err = os.Mkdir(benchDir, 0775)
 	// Ignore the error -- TODO note the difference between exists already and other errors.
+	for _, config := range todo.Configurations {
+		if !config.Disabled && (len(config.Profile) > 0 || config.Trace) {
+			if err := os.MkdirAll(profileDir(), 0775); err != nil {
+				fmt.Printf("There was an error creating %s, %v\n", profileDir(), err)
+				os.Exit(2)
+			}
+			break
+		}
+	}
 
 	for i, config := range todo.Configurations {
 		if !config.Disabled { // Don't overwrite if something was disabled.
@@ -516,6 +686,24 @@ ADD . /
 				os.Exit(2)
 			}
 			todo.Configurations[i].benchWriter = f
+
+			s = config.thingBenchName("json")
+			f, err = os.OpenFile(s, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, os.ModePerm)
+			if err != nil {
+				fmt.Printf("There was an error opening %s for output, error %v\n", s, err)
+				os.Exit(2)
+			}
+			todo.Configurations[i].eventWriter = f
+
+			if test && retries > 0 {
+				s = config.thingBenchName("retry")
+				f, err = os.OpenFile(s, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, os.ModePerm)
+				if err != nil {
+					fmt.Printf("There was an error opening %s for output, error %v\n", s, err)
+					os.Exit(2)
+				}
+				todo.Configurations[i].retryWriter = f
+			}
 		}
 	}
 
@@ -539,6 +727,18 @@ ADD . /
 			fmt.Print("Go getting")
 		}
 
+		// getFailed records failure s to get benchmark i, which is then disabled.
+		getFailed := func(i int, s string) {
+			bench := &todo.Benchmarks[i]
+			fmt.Println(s + "DISABLING benchmark " + bench.Name)
+			getAndBuildFailures = append(getAndBuildFailures, s+"("+bench.Name+")\n")
+			bench.Disabled = true
+			bench.skipReason = "failed to get"
+			if policy.failed(bench) {
+				stop(1)
+			}
+		}
+
 		// Obtain (go get -d -t -v bench.Repo) all benchmarks, once, populating src
 		for i, bench := range todo.Benchmarks {
 			if bench.Disabled {
@@ -561,9 +761,7 @@ ADD . /
 			if err != nil {
 				ee := err.(*exec.ExitError)
 				s := fmt.Sprintf("There was an error running 'go get', stderr = %s", ee.Stderr)
-				fmt.Println(s + "DISABLING benchmark " + bench.Name)
-				getAndBuildFailures = append(getAndBuildFailures, s+"("+bench.Name+")\n")
-				todo.Benchmarks[i].Disabled = true
+				getFailed(i, s)
 				continue
 			}
 
@@ -577,9 +775,7 @@ ADD . /
 				repoAt := pathLengths[root] - 1
 				if repoAt < 1 || repoAt >= len(parts) {
 					s := fmt.Sprintf("repoAt=%d was not a valid index for %v", repoAt, parts)
-					fmt.Println(s + "DISABLING benchmark " + bench.Name)
-					getAndBuildFailures = append(getAndBuildFailures, s+"("+bench.Name+")\n")
-					todo.Benchmarks[i].Disabled = true
+					getFailed(i, s)
 					continue
 				}
 				dirToMake := gopath + "/src/" + strings.Join(parts[:repoAt], "/")
@@ -590,9 +786,7 @@ ADD . /
 				err := os.MkdirAll(dirToMake, 0777)
 				if err != nil {
 					s := fmt.Sprintf("could not os.MkdirAll(%s), err = %v", dirToMake, err)
-					fmt.Println(s + "DISABLING benchmark " + bench.Name)
-					getAndBuildFailures = append(getAndBuildFailures, s+"("+bench.Name+")\n")
-					todo.Benchmarks[i].Disabled = true
+					getFailed(i, s)
 					continue
 				}
 
@@ -608,9 +802,7 @@ ADD . /
 				if err != nil {
 					ee := err.(*exec.ExitError)
 					s := fmt.Sprintf("There was an error running 'git clone', stderr = %s", ee.Stderr)
-					fmt.Println(s + "DISABLING benchmark " + bench.Name)
-					getAndBuildFailures = append(getAndBuildFailures, s+"("+bench.Name+")\n")
-					todo.Benchmarks[i].Disabled = true
+					getFailed(i, s)
 					continue
 				}
 				// This next bit often doesn't work, because reasons.
@@ -686,6 +878,12 @@ ADD . /
 
 			root := config.Root
 
+			if config.pgoFrom != "" {
+				// Shares the GOROOT of the configuration it is derived from.
+				todo.Configurations[ci].rootCopy = goroots + "/" + config.pgoFrom + "/"
+				continue
+			}
+
 			rootCopy := goroots + "/" + config.Name + "/"
 			if verbose > 0 {
 				fmt.Printf("rm -rf %s\n", rootCopy)
@@ -696,14 +894,15 @@ ADD . /
 
 			docopy := func(from, to string) {
 				mkdir := exec.Command("mkdir", "-p", to)
-				s, _ := config.runBinary("", mkdir, false)
+				s, _ := config.runBinary("", mkdir, false, nil, nil, nil)
 				if s != "" {
 					fmt.Println("Error creating directory, ", to)
 					config.Disabled = true
+					config.skipReason = "could not create " + to
 				}
 
 				cp := exec.Command("rsync", "-a", from+"/", to)
-				s, _ = config.runBinary("", cp, false)
+				s, _ = config.runBinary("", cp, false, nil, nil, nil)
 				if s != "" {
 					fmt.Println("Error copying directory tree, ", from, to)
 					// Not disabling because gollvm uses a different directory structure
@@ -735,11 +934,17 @@ ADD . /
 					cmd.Env = replaceEnv(cmd.Env, "GOROOT", rootCopy)
 				}
 				cmd.Env = replaceEnvs(cmd.Env, config.GcEnv)
+				cmd.Env = config.targetEnv(cmd.Env)
 
-				s, _ := config.runBinary("", cmd, true)
+				s, _ := config.runBinary("", cmd, true, nil, nil, nil)
 				if s != "" {
 					fmt.Println("Error running go install std, ", s)
+					getAndBuildFailures = append(getAndBuildFailures, s+"(configuration "+config.Name+")\n")
 					config.Disabled = true
+					config.skipReason = "failed to build the standard library"
+					if policy.failed(nil) {
+						stop(1)
+					}
 				}
 			}
 
@@ -770,13 +975,11 @@ ADD . /
 						continue
 					}
 					for ci, config := range todo.Configurations {
-						if config.Disabled {
+						if config.Disabled || config.pgoFrom != "" || config.buildFailed(bench.Name) {
 							continue
 						}
 						s := todo.Configurations[ci].compileOne(&todo.Benchmarks[bi], cwd, yyy)
-						if s != "" {
-							getAndBuildFailures = append(getAndBuildFailures, s)
-						}
+						buildFailed(&todo.Benchmarks[bi], s)
 					}
 				}
 			}
@@ -796,13 +999,11 @@ ADD . /
 
 					for ci := range todo.Configurations {
 						config := &todo.Configurations[permute[ci]]
-						if config.Disabled {
+						if config.Disabled || config.pgoFrom != "" || config.buildFailed(bench.Name) {
 							continue
 						}
 						s := config.compileOne(&todo.Benchmarks[bi], cwd, yyy)
-						if s != "" {
-							getAndBuildFailures = append(getAndBuildFailures, s)
-						}
+						buildFailed(&todo.Benchmarks[bi], s)
 					}
 				}
 			}
@@ -821,13 +1022,11 @@ ADD . /
 				for _, p := range permute {
 					bench := &todo.Benchmarks[p.b]
 					config := &todo.Configurations[p.c]
-					if bench.Disabled || config.Disabled {
+					if bench.Disabled || config.Disabled || config.pgoFrom != "" || config.buildFailed(bench.Name) {
 						continue
 					}
 					s := config.compileOne(bench, cwd, yyy)
-					if s != "" {
-						getAndBuildFailures = append(getAndBuildFailures, s)
-					}
+					buildFailed(bench, s)
 				}
 			}
 
@@ -847,13 +1046,11 @@ ADD . /
 			for _, p := range permute {
 				bench := &todo.Benchmarks[p.b]
 				config := &todo.Configurations[p.c]
-				if bench.Disabled || config.Disabled {
+				if bench.Disabled || config.Disabled || config.pgoFrom != "" || config.buildFailed(bench.Name) {
 					continue
 				}
 				s := config.compileOne(bench, cwd, p.k)
-				if s != "" {
-					getAndBuildFailures = append(getAndBuildFailures, s)
-				}
+				buildFailed(bench, s)
 			}
 		}
 
@@ -861,7 +1058,7 @@ ADD . /
 			fmt.Println()
 		}
 
-		// As needed, create the sandbox.
+		// As needed, create the sandbox, which +PGO configurations also need for profiling.
 		if needSandbox {
 			if verbose == 0 {
 				fmt.Print("Making sandbox")
@@ -884,39 +1081,23 @@ ADD . /
 			}
 			fmt.Printf("Container for sandboxed bench/test runs is %s\n", container)
 		}
+
+		// +PGO configurations need profiles from running the benchmarks built above.
+		todo.buildPGO(cwd, container, buildCount, buildFailed)
+
+		for ci := range todo.Configurations {
+			todo.Configurations[ci].writePhases()
+		}
+		todo.diffDiagnostics()
 	} else {
 		container = runContainer
+		todo.checkBinaries(cwd, buildFailed)
 		if getOnly { // -r -g is a bit of a no-op, but that's what it implies.
 			return
 		}
 	}
 
-	var failures []string
-
-	// If there's a bad error running one of the benchmarks, report what we've got, please.
-	defer func(t *Todo) {
-		for _, config := range todo.Configurations {
-			if !config.Disabled { // Don't overwrite if something was disabled.
-				config.benchWriter.Close()
-			}
-		}
-		if needSandbox {
-			// Print this a second time so it doesn't get missed.
-			fmt.Printf("Container for sandboxed bench/test runs is %s\n", container)
-		}
-		if len(failures) > 0 {
-			fmt.Println("FAILURES:")
-			for _, f := range failures {
-				fmt.Println(f)
-			}
-		}
-		if len(getAndBuildFailures) > 0 {
-			fmt.Println("Get and build failures:")
-			for _, f := range getAndBuildFailures {
-				fmt.Println(f)
-			}
-		}
-	}(todo)
+	todo.prepareTargets(cwd, buildFailed)
 
 	maxrc := 0
 
@@ -929,102 +1110,119 @@ ADD . /
 				continue
 			}
 
-			for _, b := range todo.Benchmarks {
-				if b.Disabled {
-					continue
+			// run runs the test binary for b in this configuration once;
+			// retry is nonzero for reruns of a failed test, see -retry.
+			run := func(b *Benchmark, retry int) (string, int, *testConverter) {
+				var s string
+				var rc int
+				var errLog bytes.Buffer
+
+				conv := newTestConverter(testEvent{Package: b.Repo, Benchmark: b.Name, Config: config.Name, Run: i, Retry: retry},
+					teeEvents(jsonEventWriter(config.eventWriter), tests.record))
+				if test && !verboseRequested(b, &config, moreArgs) {
+					conv.quiet = newQuietFilter() // .stdout gets what it would have without -test.v
 				}
 
-				root := config.Root
+				// Profiles and traces are collected from the first try only, not from reruns of failed tests.
+				var profile func(dir string) []string
+				if retry == 0 {
+					profile = func(dir string) []string { return config.profileArgs(b, i, dir) }
+				}
+				cmd, profiled := config.runCommand(b, cwd, container, i, profileDir(), profile, moreArgs)
 
-				wrapperPrefix := "/"
-				if b.NotSandboxed {
-					wrapperPrefix = cwd + "/"
+				var perf *perfCounters
+				if countPerf && retry == 0 && b.NotSandboxed && config.executor(gopath) == nil {
+					perf = &perfCounters{}
+				}
+				// Reruns' output goes to .retry, so that .stdout has the same runs as without -retry.
+				runner := &todo.Configurations[j]
+				if retry > 0 {
+					r := *runner
+					r.benchWriter = config.retryWriter
+					fmt.Fprintf(r.benchWriter, "=== %s in %s, run %d, retry %d (-test.run=%s)\n", b.Name, config.Name, i, retry, b.Tests)
+					runner = &r
 				}
-				wrapperFor := func(s []string) string {
-					x := ""
-					if len(s) > 0 {
-						// If not an explicit path, then make it an explicit path
-						x = s[0]
-						if x[0] != '/' {
-							x = wrapperPrefix + x
+				s, rc = runner.runBinary(cwd, cmd, false, conv, &errLog, perf)
+				if perf != nil {
+					if line := perfLine(b, perf.stop()); line != "" {
+						config.benchWriter.WriteString(line)
+						if verbose > 0 {
+							fmt.Print(line)
 						}
 					}
-					return x
 				}
-
-				configWrapper := wrapperFor(config.RunWrapper)
-				benchWrapper := wrapperFor(b.RunWrapper)
-
-				testBinaryName := config.benchName(&b)
-				var s string
-				var rc int
-
-				var wrappersAndBin []string
-
-				if configWrapper != "" {
-					wrappersAndBin = append(wrappersAndBin, configWrapper)
-					wrappersAndBin = append(wrappersAndBin, config.RunWrapper[1:]...)
+				conv.finish(rc)
+				if config.Trace && profiled {
+					trace := profileDir() + "/" + config.traceName(b, i)
+					if st, err := summarizeTrace(trace); err != nil {
+						fmt.Printf("Could not summarize the execution trace %s, %v\n", trace, err)
+					} else {
+						line := traceLine(b, st)
+						config.benchWriter.WriteString(line)
+						if verbose > 0 {
+							fmt.Print(line)
+						}
+					}
 				}
-				if benchWrapper != "" {
-					wrappersAndBin = append(wrappersAndBin, benchWrapper)
-					wrappersAndBin = append(wrappersAndBin, b.RunWrapper[1:]...)
+				if gctrace && retry == 0 && rc == 0 { // A failed run's GC summary is partial.
+					line := gcLine(b, parseGCTrace(errLog.Bytes()))
+					config.benchWriter.WriteString(line)
+					if verbose > 0 {
+						fmt.Print(line)
+					}
 				}
-
-				if b.NotSandboxed {
-					testdir := gopath + "/src/" + b.Repo
-					bin := cwd + "/" + testBinDir + "/" + testBinaryName
-					wrappersAndBin = append(wrappersAndBin, bin)
-
-					cmd := exec.Command(wrappersAndBin[0], wrappersAndBin[1:]...)
-					cmd.Args = append(cmd.Args, "-test.run="+b.Tests)
-					cmd.Args = append(cmd.Args, "-test.bench="+b.Benchmarks)
-
-					cmd.Dir = testdir
-					cmd.Env = defaultEnv
-					if root != "" {
-						cmd.Env = replaceEnv(cmd.Env, "GOROOT", root)
+				if s != "" || rc != 0 {
+					header := fmt.Sprintf("=== %s in %s, run %d", b.Name, config.Name, i)
+					if retry > 0 {
+						header += fmt.Sprintf(", retry %d (-test.run=%s)", retry, b.Tests)
 					}
-					cmd.Env = replaceEnvs(cmd.Env, config.RunEnv)
-					cmd.Env = append(cmd.Env, "BENT_DIR="+cwd)
-					cmd.Env = append(cmd.Env, "BENT_BINARY="+testBinaryName)
-					cmd.Env = append(cmd.Env, "BENT_I="+strconv.FormatInt(int64(i), 10))
-					cmd.Args = append(cmd.Args, config.RunFlags...)
-					cmd.Args = append(cmd.Args, moreArgs...)
-					s, rc = todo.Configurations[j].runBinary(cwd, cmd, false)
-				} else {
-					// docker run --net=none -e GOROOT=... -w /src/github.com/minio/minio/cmd $D /testbin/cmd_Config.test -test.short -test.run=Nope -test.v -test.bench=Benchmark'(Get|Put|List)'
-					testdir := "/gopath/src/" + b.Repo
-					bin := "/" + testBinDir + "/" + testBinaryName
-					wrappersAndBin = append(wrappersAndBin, bin)
-
-					cmd := exec.Command("docker", "run", "--net=none",
-						"-w", testdir)
-					for _, e := range config.RunEnv {
-						cmd.Args = append(cmd.Args, "-e", e)
+					header += fmt.Sprintf(", rc = %d\n", rc)
+					if log := config.appendLog(b, "stderr", header, errLog.Bytes()); log != "" && retry == 0 {
+						s = fmt.Sprintf("Running %s in %s failed, rc = %d, stderr in %s\n", b.Name, config.Name, rc, log)
 					}
-					cmd.Args = append(cmd.Args, "-e", "BENT_DIR=/") // TODO this is not going to work well
-					cmd.Args = append(cmd.Args, "-e", "BENT_BINARY="+testBinaryName)
-					cmd.Args = append(cmd.Args, "-e", "BENT_I="+strconv.FormatInt(int64(i), 10))
-					cmd.Args = append(cmd.Args, container)
-					cmd.Args = append(cmd.Args, wrappersAndBin...)
-					cmd.Args = append(cmd.Args, "-test.run="+b.Tests)
-					cmd.Args = append(cmd.Args, "-test.bench="+b.Benchmarks)
-					cmd.Args = append(cmd.Args, config.RunFlags...)
-					cmd.Args = append(cmd.Args, moreArgs...)
-					s, rc = todo.Configurations[j].runBinary(cwd, cmd, false)
 				}
+				return s, rc, conv
+			}
+
+			for _, b := range todo.Benchmarks {
+				if b.Disabled || config.buildFailed(b.Name) {
+					continue
+				}
+
+				s, rc, conv := run(&b, 0)
 				if s != "" {
 					fmt.Println(s)
 					failures = append(failures, s)
 				}
-				if rc > maxrc {
-					maxrc = rc
+				if (s != "" || rc != 0) && !b.AllowFailure {
+					if rc > maxrc {
+						maxrc = rc
+					}
+					if policy.failed(&b) {
+						stop(maxrc)
+					}
+				}
+
+				// Rerun each failed test by itself, to see if it is flaky.
+				// The original failure still counts.
+				if test && rc != 0 {
+					for _, t := range conv.failed {
+						rb := b
+						rb.Tests = "^" + regexp.QuoteMeta(t) + "$"
+						for r := 1; r <= retries; r++ {
+							fmt.Printf("Retrying %s for %s in %s (%d of %d)\n", t, b.Name, config.Name, r, retries)
+							if _, rc, _ := run(&rb, r); rc == 0 {
+								break
+							}
+						}
+					}
 				}
 			}
 		}
 	}
-	if maxrc > 0 {
-		os.Exit(maxrc)
+	if rc := policy.exitCode(maxrc); rc > 0 {
+		summarize()
+		os.Exit(rc)
 	}
 }
 
@@ -1039,10 +1237,204 @@ func (c *Configuration) thingBenchName(suffix string) string {
 	return benchDir + "/" + runstamp + "." + c.Name + "." + suffix
 }
 
+// runBenchName returns the name of a file in the bench directory for this
+// run that is not specific to any configuration.
+func runBenchName(suffix string) string {
+	return benchDir + "/" + runstamp + "." + suffix
+}
+
 func (c *Configuration) benchName(b *Benchmark) string {
 	return b.Name + "_" + c.Name
 }
 
+// runArgs returns the flags for running the test binary for b in configuration c.
+// When running tests, -test.v comes first.  Because the last setting of a flag wins, the order is b's BenchTime and Count,
+// then b's RunFlags, then c's RunFlags; any command-line arguments follow these.
+func (c *Configuration) runArgs(b *Benchmark) []string {
+	var args []string
+	if test { // -test.v is needed to report each test's result; see quietFilter for .stdout.
+		args = append(args, "-test.v")
+	}
+	args = append(args, "-test.run="+b.Tests, "-test.bench="+b.Benchmarks)
+	if b.BenchTime != "" {
+		args = append(args, "-test.benchtime="+b.BenchTime)
+	}
+	if b.Count > 0 {
+		args = append(args, "-test.count="+strconv.Itoa(b.Count))
+	}
+	args = append(args, b.RunFlags...)
+	args = append(args, c.RunFlags...)
+	return args
+}
+
+// verboseRequested reports whether -test.v is among the flags for running
+// b in c, other than the one runArgs adds when running tests.
+func verboseRequested(b *Benchmark, c *Configuration, moreArgs []string) bool {
+	for _, args := range [][]string{b.RunFlags, c.RunFlags, moreArgs} {
+		for _, a := range args {
+			a = strings.TrimPrefix(a, "-")
+			if a == "-test.v" || a == "test.v" || strings.HasPrefix(a, "test.v=") && a != "test.v=false" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// runEnv returns the extra environment for running the test binary for b
+// in configuration c; c's RunEnv overrides b's for the same variable.
+// With -gctrace, GODEBUG also includes gctrace=1.
+func (c *Configuration) runEnv(b *Benchmark) []string {
+	env := replaceEnvs(append([]string(nil), b.RunEnv...), c.RunEnv)
+	if gctrace {
+		env = withGODEBUG(env, "gctrace=1")
+	}
+	return env
+}
+
+// runCommand returns the command that runs the test binary for b in
+// configuration c, for iteration i: in the sandbox container, on the build
+// host if b is NotSandboxed, or with c's executor if it has one, wrapped in
+// c's and b's RunWrappers, with moreArgs following c's runArgs.  If profile
+// is not nil, it returns the flags for writing profiles to dir, which is
+// profiles in cwd, or mounted in the container; runCommand also reports
+// whether these were included, since they are not for a remote executor.
+func (c *Configuration) runCommand(b *Benchmark, cwd, container string, i int, profiles string, profile func(dir string) []string, moreArgs []string) (*exec.Cmd, bool) {
+	root := c.Root
+	gopath := cwd + "/gopath"
+
+	// Emulated binaries for sandboxed benchmarks run in the container, with the host's QEMU.
+	x := c.executor(gopath)
+	qemu, emulated := x.(*qemuExecutor)
+	if emulated && !b.NotSandboxed {
+		x = nil
+	}
+
+	wrapperPrefix := "/"
+	if b.NotSandboxed || x != nil {
+		wrapperPrefix = cwd + "/"
+	}
+	wrapperFor := func(s []string) string {
+		x := ""
+		if len(s) > 0 {
+			// If not an explicit path, then make it an explicit path
+			x = s[0]
+			if x[0] != '/' {
+				x = wrapperPrefix + x
+			}
+		}
+		return x
+	}
+
+	configWrapper := wrapperFor(c.RunWrapper)
+	benchWrapper := wrapperFor(b.RunWrapper)
+
+	testBinaryName := c.benchName(b)
+
+	var wrappersAndBin []string
+
+	if configWrapper != "" {
+		wrappersAndBin = append(wrappersAndBin, configWrapper)
+		wrappersAndBin = append(wrappersAndBin, c.RunWrapper[1:]...)
+	}
+	if benchWrapper != "" {
+		wrappersAndBin = append(wrappersAndBin, benchWrapper)
+		wrappersAndBin = append(wrappersAndBin, b.RunWrapper[1:]...)
+	}
+
+	var profileArgs []string
+	if profile != nil {
+		profileArgs = profile(cwd + "/" + profiles)
+	}
+
+	if x != nil {
+		// Built for another target, run it with the target's executor.
+		bin := cwd + "/" + testBinDir + "/" + testBinaryName
+		var env []string
+		if emulated && root != "" {
+			env = append(env, "GOROOT="+root)
+		}
+		env = append(env, c.runEnv(b)...)
+		env = append(env, "BENT_BINARY="+testBinaryName, "BENT_I="+strconv.FormatInt(int64(i), 10))
+		args := c.runArgs(b)
+		if _, remote := x.(*templateExecutor); remote {
+			profileArgs = nil // Written on the target, not here.
+		}
+		args = append(args, profileArgs...)
+		cmd := x.command(b, bin, env, append(args, moreArgs...))
+		if len(wrappersAndBin) > 0 {
+			// Wrappers run on the build host, around the executor.
+			wrappersAndBin = append(wrappersAndBin, cmd.Args...)
+			env := cmd.Env
+			cmd = exec.Command(wrappersAndBin[0], wrappersAndBin[1:]...)
+			cmd.Env = env
+		}
+		cmd.Dir = gopath + "/src/" + b.Repo
+		cmd.Env = replaceEnvs(defaultEnv, cmd.Env)
+		cmd.Env = replaceEnvs(cmd.Env, []string{"BENT_DIR=" + cwd, "BENT_BINARY=" + testBinaryName, "BENT_I=" + strconv.FormatInt(int64(i), 10)})
+		return cmd, len(profileArgs) > 0
+	}
+
+	if b.NotSandboxed {
+		testdir := gopath + "/src/" + b.Repo
+		bin := cwd + "/" + testBinDir + "/" + testBinaryName
+		wrappersAndBin = append(wrappersAndBin, bin)
+
+		cmd := exec.Command(wrappersAndBin[0], wrappersAndBin[1:]...)
+		cmd.Args = append(cmd.Args, c.runArgs(b)...)
+
+		cmd.Dir = testdir
+		cmd.Env = defaultEnv
+		if root != "" {
+			cmd.Env = replaceEnv(cmd.Env, "GOROOT", root)
+		}
+		cmd.Env = replaceEnvs(cmd.Env, c.runEnv(b))
+		cmd.Env = append(cmd.Env, "BENT_DIR="+cwd)
+		cmd.Env = append(cmd.Env, "BENT_BINARY="+testBinaryName)
+		cmd.Env = append(cmd.Env, "BENT_I="+strconv.FormatInt(int64(i), 10))
+		cmd.Args = append(cmd.Args, profileArgs...)
+		cmd.Args = append(cmd.Args, moreArgs...)
+		return cmd, len(profileArgs) > 0
+	}
+
+	// docker run --net=none -e GOROOT=... -w /src/github.com/minio/minio/cmd $D /testbin/cmd_Config.test -test.short -test.run=Nope -test.v -test.bench=Benchmark'(Get|Put|List)'
+	testdir := "/gopath/src/" + b.Repo
+	bin := "/" + testBinDir + "/" + testBinaryName
+
+	cmd := exec.Command("docker", "run", "--net=none",
+		"-w", testdir)
+	if emulated {
+		// QEMU user-mode emulators are statically linked, so the host's runs in the container.
+		path, _ := exec.LookPath(qemu.qemu) // checked by prepare
+		cmd.Args = append(cmd.Args, "-v", path+":/"+qemu.qemu+":ro")
+		wrappersAndBin = append(wrappersAndBin, "/"+qemu.qemu)
+	}
+	wrappersAndBin = append(wrappersAndBin, bin)
+	for _, e := range c.runEnv(b) {
+		cmd.Args = append(cmd.Args, "-e", e)
+	}
+	// The container is built from this directory (see Dockerfile), but its testbin
+	// lacks the +PGO binaries built after it (see buildPGO), and with -r may be
+	// older than this one, which -r checks, so use this one.
+	cmd.Args = append(cmd.Args, "-v", cwd+"/"+testBinDir+":/"+testBinDir+":ro")
+	// The Dockerfile adds this directory at /, but as a copy, so files that wrappers
+	// write to BENT_DIR stay in the container.
+	cmd.Args = append(cmd.Args, "-e", "BENT_DIR=/") // TODO this is not going to work well
+	cmd.Args = append(cmd.Args, "-e", "BENT_BINARY="+testBinaryName)
+	cmd.Args = append(cmd.Args, "-e", "BENT_I="+strconv.FormatInt(int64(i), 10))
+	if len(profileArgs) > 0 {
+		// Profiles are written to a directory shared with the container.
+		cmd.Args = append(cmd.Args, "-v", cwd+"/"+profiles+":/profiles")
+		profileArgs = profile("/profiles")
+	}
+	cmd.Args = append(cmd.Args, container)
+	cmd.Args = append(cmd.Args, wrappersAndBin...)
+	cmd.Args = append(cmd.Args, c.runArgs(b)...)
+	cmd.Args = append(cmd.Args, profileArgs...)
+	cmd.Args = append(cmd.Args, moreArgs...)
+	return cmd, len(profileArgs) > 0
+}
+
 func (c *Configuration) goCommand() string {
 	gocmd := "go"
 	if c.Root != "" {
@@ -1059,6 +1451,37 @@ func (c *Configuration) goCommandCopy() string {
 	return gocmd
 }
 
+// logName returns the name of the file in the bench directory
+// for this run that holds the suffix log for benchmark b in configuration c,
+// e.g. bench/<runstamp>.Tip.gonum_path.buildlog
+func (c *Configuration) logName(b *Benchmark, suffix string) string {
+	return c.thingBenchName(b.Name + "." + suffix)
+}
+
+// appendLog appends header and then text to the suffix log for benchmark b
+// in configuration c, and returns the name of the log, or "" if it could not be written.
+func (c *Configuration) appendLog(b *Benchmark, suffix, header string, text []byte) string {
+	log := c.logName(b, suffix)
+	f, err := os.OpenFile(log, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0664)
+	if err != nil {
+		fmt.Printf("There was an error opening %s, %v\n", log, err)
+		return ""
+	}
+	defer f.Close()
+	f.WriteString(header)
+	if _, err := f.Write(text); err != nil {
+		fmt.Printf("There was an error writing %s, %v\n", log, err)
+		return ""
+	}
+	return log
+}
+
+// buildFailed reports whether the benchmark named b failed to build in configuration c.
+func (c *Configuration) buildFailed(b string) bool {
+	_, failed := c.notBuilt[b]
+	return failed
+}
+
 func (config *Configuration) createFilesForLater() {
 	if config.Disabled {
 		return
@@ -1085,12 +1508,16 @@ func (config *Configuration) createFilesForLater() {
 	}
 }
 
-func (config *Configuration) runOtherBenchmarks(b *Benchmark, cwd string) {
+// runOtherBenchmarks runs the configuration's AfterBuild commands for b,
+// returning a description of any that failed.
+func (config *Configuration) runOtherBenchmarks(b *Benchmark, cwd string) string {
 	// Run various other "benchmark" commands on the built binaries, e.g., size, quality of debugging information.
 	if config.Disabled {
-		return
+		return ""
 	}
 
+	failed := ""
+
 	for _, cmd := range config.AfterBuild {
 		tbn := config.thingBenchName(cmd)
 		f, err := os.OpenFile(tbn, os.O_WRONLY|os.O_APPEND, os.ModePerm)
@@ -1102,7 +1529,8 @@ func (config *Configuration) runOtherBenchmarks(b *Benchmark, cwd string) {
 		if !strings.ContainsAny(cmd, "/") {
 			cmd = cwd + "/" + cmd
 		}
-		if b.Disabled {
+		if b.Disabled || config.buildFailed(b.Name) {
+			f.Close()
 			continue
 		}
 		testBinaryName := config.benchName(b)
@@ -1127,12 +1555,15 @@ func (config *Configuration) runOtherBenchmarks(b *Benchmark, cwd string) {
 		}
 		if err != nil {
 			fmt.Printf("Error running %s\n", cmd)
+			failed += fmt.Sprintf("There was an error running %s, output = %s(%s)\n", cmd, output, b.Name)
+			f.Close()
 			continue
 		}
 		f.Write(output)
 		f.Sync()
 		f.Close()
 	}
+	return failed
 }
 
 func (config *Configuration) compileOne(bench *Benchmark, cwd string, count int) string {
@@ -1140,6 +1571,23 @@ func (config *Configuration) compileOne(bench *Benchmark, cwd string, count int)
 	gocmd := config.goCommandCopy()
 	gopath := cwd + "/gopath"
 
+	// Reuse the binary from an earlier run if nothing it depends on has changed,
+	// unless this is build benchmarking.
+	key := ""
+	if explicitAll == 0 && binCache {
+		key = config.cacheKey(bench, cwd)
+		if output, ok := config.fromCache(bench, key); ok {
+			if verbose == 0 {
+				fmt.Print("+")
+			}
+			config.replayBuild(bench, gopath, key, output)
+			if count == 0 {
+				return config.runOtherBenchmarks(bench, cwd)
+			}
+			return ""
+		}
+	}
+
 	if explicitAll != 1 { // clear cache unless "-a[=1]" which requests -a on compilation.
 		cmd := exec.Command(gocmd, "clean", "-cache")
 		cmd.Env = defaultEnv
@@ -1152,7 +1600,7 @@ func (config *Configuration) compileOne(bench *Benchmark, cwd string, count int)
 		cmd.Env = replaceEnvs(cmd.Env, bench.GcEnv)
 		cmd.Env = replaceEnvs(cmd.Env, config.GcEnv)
 		cmd.Dir = gopath // Only want the cache-cleaning effect, not the binary-deleting effect. It's okay to clean gopath.
-		s, _ := config.runBinary("", cmd, true)
+		s, _ := config.runBinary("", cmd, true, nil, nil, nil)
 		if s != "" {
 			fmt.Println("Error running go clean -cache, ", s)
 		}
@@ -1170,6 +1618,9 @@ func (config *Configuration) compileOne(bench *Benchmark, cwd string, count int)
 	if config.GcFlags != "" {
 		cmd.Args = append(cmd.Args, "-gcflags="+config.GcFlags)
 	}
+	if config.pgoFrom != "" {
+		cmd.Args = append(cmd.Args, "-pgo="+pgoProfile(cwd, config.pgoFrom, bench))
+	}
 	cmd.Args = append(cmd.Args, ".")
 	cmd.Dir = gopath + "/src/" + bench.Repo
 	cmd.Env = defaultEnv
@@ -1181,6 +1632,7 @@ func (config *Configuration) compileOne(bench *Benchmark, cwd string, count int)
 	}
 	cmd.Env = replaceEnvs(cmd.Env, bench.GcEnv)
 	cmd.Env = replaceEnvs(cmd.Env, config.GcEnv)
+	cmd.Env = config.targetEnv(cmd.Env)
 
 	if verbose > 0 {
 		fmt.Println(asCommandLine(cwd, cmd))
@@ -1199,10 +1651,31 @@ func (config *Configuration) compileOne(bench *Benchmark, cwd string, count int)
 		default:
 			s = fmt.Sprintf("There was an error running 'go test', output = %s, error = %v", output, e)
 		}
-		fmt.Println(s + "DISABLING benchmark " + bench.Name)
-		bench.Disabled = true // if it won't compile, it won't run, either.
-		return s + "(" + bench.Name + ")\n"
+		if config.notBuilt == nil {
+			config.notBuilt = make(map[string]string)
+		}
+		config.notBuilt[bench.Name] = "failed to build"
+		if policy.keepBuilt {
+			fmt.Println(s + "DISABLING benchmark " + bench.Name + " for configuration " + config.Name)
+		} else {
+			fmt.Println(s + "DISABLING benchmark " + bench.Name)
+			bench.Disabled = true // if it won't compile, it won't run, either.
+			bench.skipReason = "failed to build in configuration " + config.Name
+		}
+		// Keep the whole output in a file, and just point to it in the summary.
+		log := config.logName(bench, "buildlog")
+		if err := ioutil.WriteFile(log, output, 0664); err != nil {
+			fmt.Printf("There was an error writing %s, %v\n", log, err)
+			return s + "(" + bench.Name + ")\n"
+		}
+		config.notBuilt[bench.Name] += ", see " + log
+		if bench.skipReason != "" {
+			bench.skipReason += ", see " + log
+		}
+		return fmt.Sprintf("Building %s in %s failed, see %s\n", bench.Name, config.Name, log)
 	}
+	config.phaseTimes(bench, output)
+	config.recordDiagnostics(bench, gopath, cmd.Dir, output)
 	soutput := string(output)
 	// Capture times from the end of the output.
 	rbt := extractTime(soutput, "real")
@@ -1214,8 +1687,8 @@ func (config *Configuration) compileOne(bench *Benchmark, cwd string, count int)
 	// Report and record build stats to testbin
 
 	buf := new(bytes.Buffer)
-	configGoArch := getenv(config.GcEnv, "GOARCH")
-	if configGoArch != runtime.GOARCH && configGoArch != "" {
+	_, configGoArch := config.target()
+	if configGoArch != runtime.GOARCH {
 		s := fmt.Sprintf("goarch: %s-%s\n", runtime.GOARCH, configGoArch)
 		if verbose > 0 {
 			fmt.Print(s)
@@ -1247,6 +1720,10 @@ func (config *Configuration) compileOne(bench *Benchmark, cwd string, count int)
 		cleanup(gopath)
 		os.Exit(1)
 	}
+	config.writeMeta(bench, cwd)
+	if key != "" {
+		config.toCache(bench, key, output)
+	}
 	// Trim /usr/bin/time info from soutput, it's ugly
 	if verbose > 0 {
 		fmt.Println("mv " + from + " " + to + "")
@@ -1259,7 +1736,7 @@ func (config *Configuration) compileOne(bench *Benchmark, cwd string, count int)
 
 	// Do this here before any cleanup.
 	if count == 0 {
-		config.runOtherBenchmarks(bench, cwd)
+		return config.runOtherBenchmarks(bench, cwd)
 	}
 
 	return ""
@@ -1313,8 +1790,11 @@ func copyAsset(file string) {
 }
 
 // runBinary runs cmd and displays the output.
+// If conv is not nil, the output is also converted to test events.
+// If errLog is not nil, the standard error of cmd is also written there.
+// If perf is not nil, it counts hardware events for cmd; see perfCounters.
 // If the command returns an error, returns an error string.
-func (c *Configuration) runBinary(cwd string, cmd *exec.Cmd, printWorkingDot bool) (string, int) {
+func (c *Configuration) runBinary(cwd string, cmd *exec.Cmd, printWorkingDot bool, conv *testConverter, errLog io.Writer, perf *perfCounters) (string, int) {
 	line := asCommandLine(cwd, cmd)
 	if verbose > 0 {
 		fmt.Println(line)
@@ -1334,25 +1814,37 @@ func (c *Configuration) runBinary(cwd string, cmd *exec.Cmd, printWorkingDot boo
 	if err != nil {
 		return fmt.Sprintf("Error [stderrpipe] running '%s', %v", line, err), rc
 	}
-	err = cmd.Start()
+	if perf != nil {
+		err = perf.start(cmd)
+	} else {
+		err = cmd.Start()
+	}
 	if err != nil {
 		return fmt.Sprintf("Error [command start] running '%s', %v", line, err), rc
 	}
 
 	var mu = &sync.Mutex{}
 
-	f := func(r *bufio.Reader, done chan error) {
+	f := func(r *bufio.Reader, log io.Writer, done chan error) {
 		for {
 			bytes, err := r.ReadBytes('\n')
 			n := len(bytes)
 			if n > 0 {
 				mu.Lock()
-				nw, err := c.benchWriter.Write(bytes[0:n])
+				text := string(bytes[0:n])
+				if conv != nil {
+					conv.handleLine(text)
+					text = conv.plain(text)
+				}
+				nw, err := c.benchWriter.WriteString(text)
 				if err != nil {
-					fmt.Printf("Error writing, err = %v, nwritten = %d, nrequested = %d\n", err, nw, n)
+					fmt.Printf("Error writing, err = %v, nwritten = %d, nrequested = %d\n", err, nw, len(text))
 				}
 				c.benchWriter.Sync()
-				fmt.Print(string(bytes[0:n]))
+				fmt.Print(text)
+				if log != nil {
+					log.Write(bytes[0:n])
+				}
 				mu.Unlock()
 			}
 			if err == io.EOF || n == 0 {
@@ -1369,8 +1861,8 @@ func (c *Configuration) runBinary(cwd string, cmd *exec.Cmd, printWorkingDot boo
 	doneS := make(chan error)
 	doneE := make(chan error)
 
-	go f(bufio.NewReader(stdout), doneS)
-	go f(bufio.NewReader(stderr), doneE)
+	go f(bufio.NewReader(stdout), nil, doneS)
+	go f(bufio.NewReader(stderr), errLog, doneE)
 
 	errS := <-doneS
 	errE := <-doneE
@@ -1496,17 +1988,120 @@ func ifMissingAddEnv(env []string, ev string, evv string) []string {
 	return env
 }
 
-// csToset converts a comma-separated string into the set of strings between the commas.
-func csToSet(s string) map[string]bool {
+// A selector chooses benchmarks or configurations by name or tag,
+// from a comma-separated list of terms.  A term is a glob pattern
+// (as for path.Match) matching names, or "tag:" followed by a pattern
+// matching tags; a term preceded by "!" excludes whatever it matches.
+// Anything matched by a positive term is selected, even if disabled,
+// unless an exclusion matches it; if there are no positive terms, the
+// selection starts with everything not disabled.
+type selector struct {
+	terms []selectorTerm
+}
+
+type selectorTerm struct {
+	pattern string
+	tag     bool // match tags, not the name
+	exclude bool // "!" prefix
+	used    bool // a positive term that matched something
+}
+
+// csToSelector converts a comma-separated string into a selector,
+// or nil if the string is empty.
+func csToSelector(s string) (*selector, error) {
 	if s == "" {
+		return nil, nil
+	}
+	sel := &selector{}
+	for _, t := range strings.Split(s, ",") {
+		term := selectorTerm{pattern: t}
+		if strings.HasPrefix(term.pattern, "!") {
+			term.exclude = true
+			term.pattern = term.pattern[1:]
+		}
+		if strings.HasPrefix(term.pattern, "tag:") {
+			term.tag = true
+			term.pattern = term.pattern[len("tag:"):]
+		}
+		if _, err := path.Match(term.pattern, ""); err != nil || term.pattern == "" {
+			return nil, fmt.Errorf("bad selection term %q", t)
+		}
+		sel.terms = append(sel.terms, term)
+	}
+	return sel, nil
+}
+
+func (t *selectorTerm) matches(name string, tags []string) bool {
+	if !t.tag {
+		ok, _ := path.Match(t.pattern, name)
+		return ok
+	}
+	for _, tag := range tags {
+		if ok, _ := path.Match(t.pattern, tag); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// selects reports whether the thing with the given name, tags and
+// disabled status is selected.  A nil selector selects anything not disabled.
+func (s *selector) selects(name string, tags []string, disabled bool) bool {
+	if s == nil {
+		return !disabled
+	}
+	selected, anyPositive := !disabled, false
+	for i := range s.terms {
+		t := &s.terms[i]
+		if t.exclude {
+			continue
+		}
+		if !anyPositive {
+			selected, anyPositive = false, true
+		}
+		if t.matches(name, tags) {
+			t.used = true
+			selected = true
+		}
+	}
+	for i := range s.terms {
+		t := &s.terms[i]
+		if t.exclude && t.matches(name, tags) {
+			return false
+		}
+	}
+	return selected
+}
+
+// excludes reports whether an exclusion term in s matches the thing with the given name and tags.
+func (s *selector) excludes(name string, tags []string) bool {
+	if s == nil {
+		return false
+	}
+	for i := range s.terms {
+		if t := &s.terms[i]; t.exclude && t.matches(name, tags) {
+			return true
+		}
+	}
+	return false
+}
+
+// unused returns the positive terms in s that have not yet matched anything.
+func (s *selector) unused() []string {
+	if s == nil {
 		return nil
 	}
-	m := make(map[string]bool)
-	ss := strings.Split(s, ",")
-	for _, sss := range ss {
-		m[sss] = true
+	var r []string
+	for _, t := range s.terms {
+		if !t.exclude && !t.used {
+			if t.tag {
+				r = append(r, "tag:"+t.pattern)
+			} else {
+				r = append(r, t.pattern)
+			}
+		}
 	}
-	return m
+	return r
 }
 
 // count is a flag.Value that is like a flag.Bool and a flag.Int.
@@ -0,0 +1,98 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSelector(t *testing.T) {
+	type thing struct {
+		name     string
+		tags     []string
+		disabled bool
+	}
+	things := []thing{
+		{"Gonum_path", []string{"gonum", "short"}, false},
+		{"Gonum_topo", []string{"gonum"}, false},
+		{"Ethereum_bitutil", []string{"ethereum", "short"}, false},
+		{"Minio", nil, true},
+	}
+	tests := []struct {
+		terms  string
+		want   []string // names selected from things
+		unused []string
+		err    bool
+	}{
+		{"", []string{"Gonum_path", "Gonum_topo", "Ethereum_bitutil"}, nil, false},
+		{"Gonum_path", []string{"Gonum_path"}, nil, false},
+		{"Gonum_*", []string{"Gonum_path", "Gonum_topo"}, nil, false},
+		{"Minio", []string{"Minio"}, nil, false}, // selected by name, though disabled
+		{"M*", []string{"Minio"}, nil, false},
+		{"tag:short", []string{"Gonum_path", "Ethereum_bitutil"}, nil, false},
+		{"tag:g*", []string{"Gonum_path", "Gonum_topo"}, nil, false},
+		{"!Gonum_topo", []string{"Gonum_path", "Ethereum_bitutil"}, nil, false},
+		{"!tag:gonum", []string{"Ethereum_bitutil"}, nil, false},
+		{"tag:gonum,!Gonum_path", []string{"Gonum_topo"}, nil, false},
+		{"!Gonum_path,tag:gonum", []string{"Gonum_topo"}, nil, false},
+		{"Minio,tag:ethereum", []string{"Ethereum_bitutil", "Minio"}, nil, false},
+		{"Gonum_path,Nope,tag:nope,!Gonum_topo", []string{"Gonum_path"}, []string{"Nope", "tag:nope"}, false},
+		{"Gonum_path,!Gonum_path", nil, nil, false},
+		{"[", nil, nil, true},
+		{"Gonum_path,", nil, nil, true},
+		{"!", nil, nil, true},
+		{"tag:", nil, nil, true},
+	}
+	for _, tt := range tests {
+		s, err := csToSelector(tt.terms)
+		if tt.err {
+			if err == nil {
+				t.Errorf("%q: no error", tt.terms)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: %v", tt.terms, err)
+			continue
+		}
+		var got []string
+		for _, th := range things {
+			if s.selects(th.name, th.tags, th.disabled) {
+				got = append(got, th.name)
+			}
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%q selects %q, want %q", tt.terms, got, tt.want)
+		}
+		if unused := s.unused(); !reflect.DeepEqual(unused, tt.unused) {
+			t.Errorf("%q: unused %q, want %q", tt.terms, unused, tt.unused)
+		}
+	}
+}
+
+func TestSelectorExcludes(t *testing.T) {
+	tests := []struct {
+		terms, name string
+		tags        []string
+		want        bool
+	}{
+		{"", "Tip+PGO", nil, false},
+		{"Tip", "Tip+PGO", nil, false},
+		{"!Tip+PGO", "Tip+PGO", nil, true},
+		{"Tip,!*+PGO", "Tip+PGO", nil, true},
+		{"!tag:pgo", "Tip+PGO", []string{"pgo"}, true},
+		{"!tag:pgo", "Tip", nil, false},
+	}
+	for _, tt := range tests {
+		s, err := csToSelector(tt.terms)
+		if err != nil {
+			t.Fatalf("%q: %v", tt.terms, err)
+		}
+		if got := s.excludes(tt.name, tt.tags); got != tt.want {
+			t.Errorf("%q excludes %s %v = %v, want %v", tt.terms, tt.name, tt.tags, got, tt.want)
+		}
+	}
+}
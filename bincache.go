@@ -0,0 +1,315 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// binCacheDir holds test binaries from earlier runs, named by their cacheKey,
+// so that (unless -bin-cache=false) benchmarks whose source and configuration are
+// unchanged need not be rebuilt when only running them.  Each binary's build
+// output is kept with it (see outputName), so that its phase times and compiler
+// diagnostics can still be reported.  It is not used when benchmarking builds (-a).
+var binCacheDir = "bincache"
+
+// outputName returns the name of the file holding the build output for the cached binary bin.
+func outputName(bin string) string {
+	return bin + ".out"
+}
+
+// sourceRevision returns the git commit of the checkout containing dir,
+// followed by a hash of any uncommitted changes, or "" if dir is not in a
+// git checkout.
+func sourceRevision(dir string) string {
+	git := func(args ...string) string {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		out, err := cmd.Output()
+		if err != nil {
+			return ""
+		}
+		return string(out)
+	}
+	head := strings.TrimSpace(git("rev-parse", "HEAD"))
+	if head == "" {
+		return ""
+	}
+	changes := git("status", "--porcelain") + git("diff", "HEAD")
+	if changes == "" {
+		return head
+	}
+	return fmt.Sprintf("%s+%x", head, sha256.Sum256([]byte(changes)))
+}
+
+// toolchainID identifies c's Go toolchain for the binary cache: the output
+// of its "go version", and if its GOROOT is a git checkout, the commit and
+// any uncommitted changes.  It is computed once, from c's Root, not its copy.
+func (c *Configuration) toolchainID() string {
+	if c.toolchain != "" {
+		return c.toolchain
+	}
+	version, err := exec.Command(c.goCommand(), "version").Output()
+	if err != nil {
+		return ""
+	}
+	root := c.Root
+	if root == "" {
+		out, _ := exec.Command("go", "env", "GOROOT").Output()
+		root = strings.TrimSpace(string(out))
+	}
+	c.toolchain = strings.TrimSpace(string(version) + " " + sourceRevision(root))
+	return c.toolchain
+}
+
+// hostPathVars are the variables reported by "go env" that name files or
+// directories on the build host, or vary from one invocation to the next,
+// and so are left out of goEnvSettings.
+var hostPathVars = map[string]bool{
+	"GOBIN": true, "GOCACHE": true, "GOENV": true, "GOGCCFLAGS": true, "GOMOD": true,
+	"GOMODCACHE": true, "GOPATH": true, "GOROOT": true, "GOTELEMETRY": true, "GOTELEMETRYDIR": true,
+	"GOTMPDIR": true, "GOTOOLDIR": true, "GOWORK": true,
+}
+
+// goEnvCache holds the results of goEnvSettings, by go command and environment.
+var goEnvCache = make(map[string][]string)
+
+// goEnvSettings returns the settings, as VAR=value, that "go env" reports for
+// building b in configuration c, except for hostPathVars.  These include
+// GOEXPERIMENT, GOAMD64, CGO_ENABLED and the like, however they were set: in
+// b's or c's GcEnv, in bent's environment, or with "go env -w".
+func (c *Configuration) goEnvSettings(b *Benchmark) []string {
+	env := defaultEnv
+	if !b.NotSandboxed {
+		env = replaceEnv(env, "GOOS", "linux")
+	}
+	if c.Root != "" {
+		env = replaceEnv(env, "GOROOT", c.Root)
+	}
+	env = replaceEnvs(env, b.GcEnv)
+	env = replaceEnvs(env, c.GcEnv)
+	env = c.targetEnv(env)
+	key := c.goCommand() + "\x00" + strings.Join(env, "\x00")
+	if settings, ok := goEnvCache[key]; ok {
+		return settings
+	}
+	vars := make(map[string]string)
+	cmd := exec.Command(c.goCommand(), "env", "-json")
+	cmd.Env = env
+	out, err := cmd.Output()
+	if err == nil {
+		err = json.Unmarshal(out, &vars)
+	}
+	if err != nil {
+		// Fall back to what the environment sets explicitly.
+		fmt.Printf("There was an error running %s env, %v\n", c.goCommand(), err)
+		for _, e := range env {
+			if eq := strings.IndexByte(e, '='); eq > 0 && (strings.HasPrefix(e, "GO") || strings.HasPrefix(e, "CGO_")) {
+				vars[e[:eq]] = e[eq+1:]
+			}
+		}
+	}
+	var settings []string
+	for k, v := range vars {
+		if !hostPathVars[k] && v != "" {
+			settings = append(settings, k+"="+v)
+		}
+	}
+	sort.Strings(settings)
+	goEnvCache[key] = settings
+	return settings
+}
+
+// hashFile returns name and the hash of its contents, or "" if it does not exist.
+func hashFile(name string) string {
+	data, err := ioutil.ReadFile(name)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("%s=%x", filepath.Base(name), sha256.Sum256(data))
+}
+
+// depCache holds the results of dependencies, by module root, or "" for GOPATH mode.
+var depCache = make(map[string][]string)
+
+// dependencies returns the revisions of the source outside b's Repo that
+// its test binary is built from, and whether all of that source is in git
+// checkouts (otherwise, the binary is not cached).  For a benchmark in a
+// module, these are the hashes of its go.mod and go.sum, which pin the versions
+// of its requirements, and the revisions of any local directories replacing
+// them.  In GOPATH mode, they are the revisions of every checkout in gopath/src,
+// since any of them might be imported.
+func dependencies(b *Benchmark, cwd string) ([]string, bool) {
+	src := cwd + "/gopath/src"
+	root := ""
+	for dir := src + "/" + b.Repo; strings.HasPrefix(dir, src+"/"); dir = filepath.Dir(dir) {
+		if _, err := os.Stat(dir + "/go.mod"); err == nil {
+			root = dir
+			break
+		}
+	}
+	if deps, ok := depCache[root]; ok {
+		return deps, !strings.Contains(strings.Join(deps, "\n"), "(not in git)")
+	}
+	var deps []string
+	rev := func(dir string) {
+		r := sourceRevision(dir)
+		if r == "" {
+			r = "(not in git)"
+		}
+		rel, err := filepath.Rel(src, dir)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			rel = dir
+		}
+		deps = append(deps, rel+"@"+r)
+	}
+	if root == "" {
+		filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+			if err == nil && info.IsDir() && info.Name() == ".git" {
+				rev(filepath.Dir(path))
+				return filepath.SkipDir
+			}
+			return nil
+		})
+	} else {
+		for _, f := range []string{"go.mod", "go.sum"} {
+			if h := hashFile(root + "/" + f); h != "" {
+				deps = append(deps, h)
+			}
+		}
+		gomod, _ := ioutil.ReadFile(root + "/go.mod")
+		for _, l := range strings.Split(string(gomod), "\n") {
+			i := strings.Index(l, "=>")
+			if i < 0 {
+				continue
+			}
+			if to := strings.Fields(l[i+2:]); len(to) > 0 && (strings.HasPrefix(to[0], ".") || filepath.IsAbs(to[0])) {
+				dir := to[0]
+				if !filepath.IsAbs(dir) {
+					dir = filepath.Join(root, dir)
+				}
+				rev(dir)
+			}
+		}
+	}
+	depCache[root] = deps
+	return deps, !strings.Contains(strings.Join(deps, "\n"), "(not in git)")
+}
+
+// cacheKey returns the name of b's test binary for configuration c in the
+// binary cache, a hash of everything that determines it (see buildMeta).
+// It returns "" if the binary should not be cached, because its source or
+// that of its dependencies is not in a git checkout, or because it is built
+// with a profile from this run.
+func (c *Configuration) cacheKey(b *Benchmark, cwd string) string {
+	if c.pgoFrom != "" {
+		return ""
+	}
+	m := c.buildMeta(b, cwd)
+	if m.Toolchain == "" || m.Source == "" {
+		return ""
+	}
+	if _, ok := dependencies(b, cwd); !ok {
+		return ""
+	}
+	return m.Hash
+}
+
+// copyFile copies from to to, replacing to, with mode 0755.
+func copyFile(from, to string) error {
+	in, err := os.Open(from)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	tmp := to + ".tmp"
+	out, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0755)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(out, in)
+	if cerr := out.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, to)
+}
+
+// fromCache copies the cached binary with key, if there is one, to b's
+// test binary for c, and returns the output from building it.
+func (c *Configuration) fromCache(b *Benchmark, key string) ([]byte, bool) {
+	if key == "" {
+		return nil, false
+	}
+	cached := binCacheDir + "/" + key
+	if _, err := os.Stat(cached); err != nil {
+		return nil, false
+	}
+	output, err := ioutil.ReadFile(outputName(cached))
+	if err != nil {
+		// Cached by an older bent, without its output; rebuild it.
+		return nil, false
+	}
+	to := testBinDir + "/" + c.benchName(b)
+	if verbose > 0 {
+		fmt.Printf("cp %s %s # %s in %s is unchanged\n", cached, to, b.Name, c.Name)
+	}
+	if err := copyFile(cached, to); err != nil {
+		fmt.Printf("There was an error copying %s to %s, %v; rebuilding\n", cached, to, err)
+		return nil, false
+	}
+	// The metadata says when the binary was actually built.
+	if err := copyFile(metaName(cached), metaName(to)); err != nil {
+		os.Remove(metaName(to))
+	}
+	return output, true
+}
+
+// replayBuild reports what building b's test binary in c, which is
+// from the cache with key, reported when it was built: its phase times and
+// compiler diagnostics, but not its build time, which says nothing about
+// this run.  Instead the build benchmark file notes that it was not built.
+func (c *Configuration) replayBuild(b *Benchmark, gopath, key string, output []byte) {
+	c.phaseTimes(b, output)
+	c.recordDiagnostics(b, gopath, gopath+"/src/"+b.Repo, output)
+	f, err := os.OpenFile(c.buildBenchName(), os.O_WRONLY|os.O_APPEND, os.ModePerm)
+	if err != nil {
+		fmt.Printf("There was an error opening %s for append, error %v\n", c.buildBenchName(), err)
+		return
+	}
+	defer f.Close()
+	fmt.Fprintf(f, "# Benchmark%s was not built, its test binary is from %s/%s\n", strings.Title(b.Name), binCacheDir, key)
+}
+
+// toCache adds b's newly built test binary for c, and the output from
+// building it, to the cache, with key.
+func (c *Configuration) toCache(b *Benchmark, key string, output []byte) {
+	if err := os.MkdirAll(binCacheDir, 0775); err != nil {
+		fmt.Printf("There was an error creating %s, %v\n", binCacheDir, err)
+		return
+	}
+	from := testBinDir + "/" + c.benchName(b)
+	if err := copyFile(from, binCacheDir+"/"+key); err != nil {
+		fmt.Printf("There was an error caching %s, %v\n", from, err)
+		return
+	}
+	copyFile(metaName(from), metaName(binCacheDir+"/"+key))
+	if err := ioutil.WriteFile(outputName(binCacheDir+"/"+key), output, 0664); err != nil {
+		fmt.Printf("There was an error caching the output from building %s, %v\n", from, err)
+		os.Remove(binCacheDir + "/" + key)
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+)
+
+// binaryMeta describes how a test binary was built.  It is written next to
+// each binary in testbin (see metaName), so that with -r, bent can check
+// that the binaries it is about to run match the current configurations.
+type binaryMeta struct {
+	Benchmark     string
+	Configuration string
+	Hash          string   // Of Toolchain, Settings, Source, and Dependencies
+	Toolchain     string   // See toolchainID
+	Settings      []string // Target, flags, and environment of the build, see goEnvSettings
+	Source        string   // See sourceRevision
+	Dependencies  []string // See dependencies
+	Built         time.Time
+	Runstamp      string
+}
+
+// metaName returns the name of the metadata file for the test binary bin.
+func metaName(bin string) string {
+	return bin + ".json"
+}
+
+// buildMeta returns the metadata, except for when it was built, of b's
+// test binary in configuration c.  It is computed once per run.
+func (c *Configuration) buildMeta(b *Benchmark, cwd string) binaryMeta {
+	if m, ok := c.metas[b.Name]; ok {
+		return m
+	}
+	goos, goarch := c.target()
+	if !b.NotSandboxed && c.Target == "" {
+		goos = "linux"
+	}
+	m := binaryMeta{
+		Benchmark:     b.Name,
+		Configuration: c.Name,
+		Toolchain:     c.toolchainID(),
+		Source:        sourceRevision(cwd + "/gopath/src/" + b.Repo),
+	}
+	m.Settings = append(m.Settings, "target="+goos+"/"+goarch, "gcflags="+c.GcFlags)
+	for _, f := range append(append([]string(nil), b.BuildFlags...), c.BuildFlags...) {
+		m.Settings = append(m.Settings, "buildflag="+f)
+	}
+	for _, e := range c.goEnvSettings(b) {
+		m.Settings = append(m.Settings, "env="+e)
+	}
+	if c.pgoFrom != "" {
+		m.Settings = append(m.Settings, "pgo="+c.pgoFrom)
+	}
+	m.Dependencies, _ = dependencies(b, cwd)
+	h := sha256.New()
+	fmt.Fprintf(h, "%q\n%q\n%q\n%q\n%q\n", m.Toolchain, m.Settings, b.Repo, m.Source, m.Dependencies)
+	m.Hash = fmt.Sprintf("%x", h.Sum(nil))
+	if c.metas == nil {
+		c.metas = make(map[string]binaryMeta)
+	}
+	c.metas[b.Name] = m
+	return m
+}
+
+// writeMeta writes the metadata for b's newly built test binary in c.
+func (c *Configuration) writeMeta(b *Benchmark, cwd string) {
+	m := c.buildMeta(b, cwd)
+	m.Built = time.Now()
+	m.Runstamp = runstamp
+	name := metaName(testBinDir + "/" + c.benchName(b))
+	data, _ := json.MarshalIndent(&m, "", "\t")
+	if err := ioutil.WriteFile(name, append(data, '\n'), 0664); err != nil {
+		fmt.Printf("There was an error writing %s, %v\n", name, err)
+	}
+}
+
+// readMeta reads the metadata for b's test binary in c.
+func (c *Configuration) readMeta(b *Benchmark) (*binaryMeta, error) {
+	data, err := ioutil.ReadFile(metaName(testBinDir + "/" + c.benchName(b)))
+	if err != nil {
+		return nil, err
+	}
+	m := &binaryMeta{}
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// checkBinaries checks, for -r, that each test binary needed for the selected
+// benchmarks and configurations exists, and that its metadata matches how
+// it would be built now.  A benchmark whose binary is missing, or (without -f)
+// does not match, is not run in that configuration; each such problem is passed
+// to failed, as a build failure would be.
+// Binaries without metadata, from older versions of bent, are run with a warning.
+func (todo *Todo) checkBinaries(cwd string, failed func(b *Benchmark, s string)) {
+	for ci := range todo.Configurations {
+		c := &todo.Configurations[ci]
+		if c.Disabled {
+			continue
+		}
+		for bi := range todo.Benchmarks {
+			b := &todo.Benchmarks[bi]
+			if b.Disabled || c.buildFailed(b.Name) {
+				continue
+			}
+			bin := testBinDir + "/" + c.benchName(b)
+			refuse := func(why string) {
+				if c.notBuilt == nil {
+					c.notBuilt = make(map[string]string)
+				}
+				c.notBuilt[b.Name] = why
+				s := fmt.Sprintf("Not running %s in %s with -r, %s\n", b.Name, c.Name, why)
+				fmt.Print(s)
+				failed(b, s)
+			}
+			if _, err := os.Stat(bin); err != nil {
+				refuse(bin + " does not exist")
+				continue
+			}
+			old, err := c.readMeta(b)
+			if err != nil {
+				fmt.Printf("Warning: cannot check %s against configuration %s, %v\n", bin, c.Name, err)
+				continue
+			}
+			now := c.buildMeta(b, cwd)
+			if old.Hash == now.Hash {
+				if verbose > 0 {
+					fmt.Printf("%s matches configuration %s, built %s\n", bin, c.Name, old.Built.Format(time.RFC3339))
+				}
+				continue
+			}
+			var differ []string
+			if old.Toolchain != now.Toolchain {
+				differ = append(differ, fmt.Sprintf("toolchain was %q, is %q", old.Toolchain, now.Toolchain))
+			}
+			if strings.Join(old.Settings, " ") != strings.Join(now.Settings, " ") {
+				differ = append(differ, fmt.Sprintf("build settings were %q, are %q", old.Settings, now.Settings))
+			}
+			if old.Source != now.Source {
+				differ = append(differ, fmt.Sprintf("source was %s, is %s", old.Source, now.Source))
+			}
+			if strings.Join(old.Dependencies, " ") != strings.Join(now.Dependencies, " ") {
+				differ = append(differ, fmt.Sprintf("dependencies were %q, are %q", old.Dependencies, now.Dependencies))
+			}
+			why := fmt.Sprintf("%s (built %s) does not match configuration %s: %s",
+				bin, old.Built.Format(time.RFC3339), c.Name, strings.Join(differ, "; "))
+			if force {
+				fmt.Printf("Warning: %s; running it anyway because of -f\n", why)
+				continue
+			}
+			refuse(why + "; rebuild it, or use -f to run it anyway")
+		}
+	}
+}
@@ -94,5 +94,7 @@ export STAMP
 
 cd "${ROOT}"
 ${PERFLOCK} bent -U -v -N=${N} -a=${B} -L=bentjobs.log -c=Old-phase,New-phase -C=configurations-cmpjob.toml "$@" | tee phases.${STAMP}.log
+RUN=`tail -1 bentjobs.log | awk -c '{print $1}'`
+echo Per-phase totals in benchmark format are in bench/${RUN}.Old-phase.phases and bench/${RUN}.New-phase.phases
 phase-times > phases.${STAMP}.csv
 
@@ -0,0 +1,226 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"github.com/BurntSushi/toml"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strings"
+)
+
+// loadTodo reads the benchmark and configuration files,
+// expands any matrices, and resolves Extends.
+func loadTodo() (*Todo, error) {
+	todo, err := readTodo(benchFile)
+	if err != nil {
+		return nil, err
+	}
+	confTodo, err := readTodo(confFile)
+	if err != nil {
+		return nil, err
+	}
+	todo.append(confTodo)
+	if err := todo.expandMatrices(); err != nil {
+		return nil, err
+	}
+	if err := todo.resolveExtends(); err != nil {
+		return nil, err
+	}
+	return todo, nil
+}
+
+// readTodo reads the benchmark or configuration file named file,
+// first reading any files it names in its Include list (relative
+// to the directory containing file).  Included benchmarks and
+// configurations precede the ones in the including file.
+// Extends is not resolved here; see resolveExtends.
+func readTodo(file string) (*Todo, error) {
+	return readTodoFrom(file, make(map[string]bool))
+}
+
+func readTodoFrom(file string, active map[string]bool) (*Todo, error) {
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return nil, err
+	}
+	if active[abs] {
+		return nil, fmt.Errorf("file %s includes itself", file)
+	}
+	active[abs] = true
+	defer delete(active, abs)
+
+	blob, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("There was an error opening or reading file %s: %v", file, err)
+	}
+	this := &Todo{}
+	md, err := toml.Decode(string(blob), this)
+	if err != nil {
+		return nil, fmt.Errorf("There was an error unmarshalling %s: %v", file, err)
+	}
+	this.recordDefined(md)
+	var undecoded []string
+	for _, k := range md.Undecoded() {
+		undecoded = append(undecoded, k.String())
+	}
+	this.recordOrigins(file, string(blob), undecoded)
+
+	todo := &Todo{}
+	for _, inc := range this.Include {
+		if !filepath.IsAbs(inc) {
+			inc = filepath.Join(filepath.Dir(file), inc)
+		}
+		included, err := readTodoFrom(inc, active)
+		if err != nil {
+			return nil, err
+		}
+		todo.append(included)
+	}
+	this.Include = nil
+	todo.append(this)
+	return todo, nil
+}
+
+// append adds the benchmarks, configurations and matrices of other to todo.
+func (todo *Todo) append(other *Todo) {
+	todo.Benchmarks = append(todo.Benchmarks, other.Benchmarks...)
+	todo.Configurations = append(todo.Configurations, other.Configurations...)
+	todo.Matrix = append(todo.Matrix, other.Matrix...)
+	todo.problems = append(todo.problems, other.problems...)
+}
+
+// recordDefined notes, for each benchmark and configuration, which keys
+// were actually present in the file, so that resolveExtends can tell an
+// explicit empty or false value apart from one that was never set.
+func (todo *Todo) recordDefined(md toml.MetaData) {
+	var defined map[string]bool
+	b, c := -1, -1
+	for _, k := range md.Keys() {
+		switch {
+		case len(k) == 1 && k[0] == "Benchmarks":
+			b++
+			defined = make(map[string]bool)
+			todo.Benchmarks[b].defined = defined
+		case len(k) == 1 && k[0] == "Configurations":
+			c++
+			defined = make(map[string]bool)
+			todo.Configurations[c].defined = defined
+		case len(k) == 2 && defined != nil:
+			defined[strings.ToLower(k[1])] = true
+		}
+	}
+}
+
+// resolveExtends fills in fields of each benchmark and configuration
+// that names a parent in its Extends field.  Any field not set in the
+// child's own entry is copied from the (resolved) parent, except for
+// Name and Disabled; Disabled is never inherited, so a disabled entry
+// can serve as a template for enabled ones.
+func (todo *Todo) resolveExtends() error {
+	configs := make(map[string]int)
+	for i, c := range todo.Configurations {
+		configs[c.Name] = i
+	}
+	state := make([]int, len(todo.Configurations)) // 0 = unvisited, 1 = in progress, 2 = done
+	var resolveConfig func(i int) error
+	resolveConfig = func(i int) error {
+		c := &todo.Configurations[i]
+		switch state[i] {
+		case 1:
+			return fmt.Errorf("configuration %s extends itself", c.Name)
+		case 2:
+			return nil
+		}
+		state[i] = 1
+		if c.Extends != "" {
+			pi, ok := configs[c.Extends]
+			if !ok {
+				return fmt.Errorf("configuration %s extends %s, which does not exist", c.Name, c.Extends)
+			}
+			if err := resolveConfig(pi); err != nil {
+				return err
+			}
+			c.inherit(&todo.Configurations[pi])
+		}
+		state[i] = 2
+		return nil
+	}
+	for i := range todo.Configurations {
+		if err := resolveConfig(i); err != nil {
+			return err
+		}
+	}
+
+	benches := make(map[string]int)
+	for i, b := range todo.Benchmarks {
+		benches[b.Name] = i
+	}
+	state = make([]int, len(todo.Benchmarks))
+	var resolveBench func(i int) error
+	resolveBench = func(i int) error {
+		b := &todo.Benchmarks[i]
+		switch state[i] {
+		case 1:
+			return fmt.Errorf("benchmark %s extends itself", b.Name)
+		case 2:
+			return nil
+		}
+		state[i] = 1
+		if b.Extends != "" {
+			pi, ok := benches[b.Extends]
+			if !ok {
+				return fmt.Errorf("benchmark %s extends %s, which does not exist", b.Name, b.Extends)
+			}
+			if err := resolveBench(pi); err != nil {
+				return err
+			}
+			b.inherit(&todo.Benchmarks[pi])
+		}
+		state[i] = 2
+		return nil
+	}
+	for i := range todo.Benchmarks {
+		if err := resolveBench(i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Configuration) inherit(p *Configuration) {
+	inheritFields(reflect.ValueOf(c).Elem(), reflect.ValueOf(p).Elem(), c.defined)
+}
+
+func (b *Benchmark) inherit(p *Benchmark) {
+	inheritFields(reflect.ValueOf(b).Elem(), reflect.ValueOf(p).Elem(), b.defined)
+}
+
+// inheritFields copies each exported field of parent into child unless
+// it is listed (in lower case) in defined.  Slices are copied, not shared,
+// because environment expansion later modifies them in place.
+func inheritFields(child, parent reflect.Value, defined map[string]bool) {
+	t := child.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" { // unexported
+			continue
+		}
+		switch f.Name {
+		case "Name", "Extends", "Disabled":
+			continue
+		}
+		if defined[strings.ToLower(f.Name)] {
+			continue
+		}
+		v := parent.Field(i)
+		if v.Kind() == reflect.Slice && !v.IsNil() {
+			v = reflect.AppendSlice(reflect.MakeSlice(v.Type(), 0, v.Len()), v)
+		}
+		child.Field(i).Set(v)
+	}
+}
@@ -0,0 +1,187 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// readTestTodo writes files, a map from name to contents, to a temporary
+// directory, and reads the todo from the first one named, "main.toml",
+// expanding matrices and resolving Extends as loadTodo does.
+func readTestTodo(t *testing.T, files map[string]string) (*Todo, error) {
+	dir := t.TempDir()
+	for name, contents := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(contents), 0664); err != nil {
+			t.Fatal(err)
+		}
+	}
+	todo, err := readTodo(filepath.Join(dir, "main.toml"))
+	if err != nil {
+		return nil, err
+	}
+	if err := todo.expandMatrices(); err != nil {
+		return nil, err
+	}
+	if err := todo.resolveExtends(); err != nil {
+		return nil, err
+	}
+	return todo, nil
+}
+
+// describe summarizes the fields of c that the tests look at.
+func describe(c *Configuration) string {
+	s := c.Name
+	if c.Disabled {
+		s += " disabled"
+	}
+	if c.Root != "" {
+		s += " Root=" + c.Root
+	}
+	if c.GcFlags != "" {
+		s += fmt.Sprintf(" GcFlags=%q", c.GcFlags)
+	}
+	for _, f := range []struct {
+		name string
+		v    []string
+	}{{"RunEnv", c.RunEnv}, {"RunWrapper", c.RunWrapper}, {"AfterBuild", c.AfterBuild}} {
+		if f.v != nil {
+			s += fmt.Sprintf(" %s=%q", f.name, f.v)
+		}
+	}
+	return s
+}
+
+func TestExtends(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  []string // describe of each configuration
+		err   string   // if not "", a substring of the expected error
+	}{
+		{"inherit", map[string]string{"main.toml": `
+[[Configurations]]
+  Name = "Base"
+  Root = "/go1"
+  GcFlags = "-l"
+  RunEnv = ["A=1"]
+  Disabled = true
+[[Configurations]]
+  Name = "Tip"
+  Extends = "Base"
+  Root = "/tip"
+`}, []string{
+			`Base disabled Root=/go1 GcFlags="-l" RunEnv=["A=1"]`,
+			`Tip Root=/tip GcFlags="-l" RunEnv=["A=1"]`,
+		}, ""},
+		{"explicit zero values", map[string]string{"main.toml": `
+[[Configurations]]
+  Name = "Tip"
+  Extends = "Base"
+  GcFlags = ""
+  RunEnv = []
+[[Configurations]]
+  Name = "Base"
+  GcFlags = "-l"
+  RunEnv = ["A=1"]
+  RunWrapper = ["cpuprofile"]
+`}, []string{
+			`Tip RunEnv=[] RunWrapper=["cpuprofile"]`,
+			`Base GcFlags="-l" RunEnv=["A=1"] RunWrapper=["cpuprofile"]`,
+		}, ""},
+		{"chain", map[string]string{"main.toml": `
+[[Configurations]]
+  Name = "C"
+  Extends = "B"
+[[Configurations]]
+  Name = "B"
+  Extends = "A"
+  RunEnv = ["B=1"]
+[[Configurations]]
+  Name = "A"
+  GcFlags = "-N"
+  RunEnv = ["A=1"]
+`}, []string{
+			`C GcFlags="-N" RunEnv=["B=1"]`,
+			`B GcFlags="-N" RunEnv=["B=1"]`,
+			`A GcFlags="-N" RunEnv=["A=1"]`,
+		}, ""},
+		{"include", map[string]string{
+			"main.toml": `
+Include = ["common.toml"]
+[[Configurations]]
+  Name = "Tip"
+  Extends = "Common"
+`,
+			"common.toml": `
+[[Configurations]]
+  Name = "Common"
+  AfterBuild = ["benchsize"]
+`}, []string{
+			`Common AfterBuild=["benchsize"]`,
+			`Tip AfterBuild=["benchsize"]`,
+		}, ""},
+		{"cycle", map[string]string{"main.toml": `
+[[Configurations]]
+  Name = "A"
+  Extends = "B"
+[[Configurations]]
+  Name = "B"
+  Extends = "A"
+`}, nil, "extends itself"},
+		{"missing parent", map[string]string{"main.toml": `
+[[Configurations]]
+  Name = "A"
+  Extends = "Nope"
+`}, nil, "A extends Nope, which does not exist"},
+		{"include cycle", map[string]string{
+			"main.toml":  `Include = ["other.toml"]`,
+			"other.toml": `Include = ["main.toml"]`,
+		}, nil, "includes itself"},
+	}
+	for _, tt := range tests {
+		todo, err := readTestTodo(t, tt.files)
+		if tt.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("%s: error %v, want one containing %q", tt.name, err, tt.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		var got []string
+		for i := range todo.Configurations {
+			got = append(got, describe(&todo.Configurations[i]))
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s:\ngot  %q\nwant %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestExtendsSlicesNotShared(t *testing.T) {
+	todo, err := readTestTodo(t, map[string]string{"main.toml": `
+[[Benchmarks]]
+  Name = "a"
+  RunEnv = ["X=$HOME"]
+[[Benchmarks]]
+  Name = "b"
+  Extends = "a"
+`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	todo.Benchmarks[1].RunEnv[0] = "X=/home/u"
+	if got := todo.Benchmarks[0].RunEnv[0]; got != "X=$HOME" {
+		t.Errorf("parent's RunEnv changed to %q with its child's", got)
+	}
+}
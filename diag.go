@@ -0,0 +1,144 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// diagRE matches a compiler diagnostic, such as those requested with
+// -gcflags=-m=2 or -d=ssa/check_bce/debug=1, e.g.
+// "./x.go:12:6: can inline f" or "/abs/y.go:3:10: Found IsInBounds".
+var diagRE = regexp.MustCompile(`^(\S+\.go):(\d+:\d+: .*)$`)
+
+// recordDiagnostics records the normalized compiler diagnostics in
+// output, from the first build of bench in c, which ran in directory dir
+// of gopath.
+// File names are made independent of the configuration (and of the
+// build host) by removing the GOPATH and GOROOT prefixes, so that the
+// diagnostics from different configurations can be compared.
+func (c *Configuration) recordDiagnostics(bench *Benchmark, gopath, dir string, output []byte) {
+	if _, ok := c.diagnostics[bench.Name]; ok {
+		return
+	}
+	prefixes := []string{
+		gopath + "/src/", gopath + "/pkg/mod/",
+		filepath.Clean(c.rootCopy) + "/src/", filepath.Clean(c.Root) + "/src/",
+	}
+	var ds []string
+	for _, l := range strings.Split(string(output), "\n") {
+		if strings.Contains(l, "\tTIME(ns)\t") { // see phaseTimeRE
+			continue
+		}
+		m := diagRE.FindStringSubmatch(l)
+		if m == nil {
+			continue
+		}
+		file := m[1]
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(dir, file)
+		}
+		for _, p := range prefixes {
+			if strings.HasPrefix(file, p) {
+				file = file[len(p):]
+				break
+			}
+		}
+		ds = append(ds, file+":"+m[2])
+	}
+	if len(ds) == 0 {
+		return
+	}
+	sort.Strings(ds)
+	if c.diagnostics == nil {
+		c.diagnostics = make(map[string][]string)
+	}
+	c.diagnostics[bench.Name] = ds
+}
+
+// diffDiagnostics writes the compiler diagnostics recorded for each
+// configuration to <runstamp>.<config>.diag, and, taking the first
+// configuration with diagnostics as the baseline, writes the
+// diagnostics added and removed by each of the others, per benchmark,
+// to <runstamp>.<baseline>-vs-<config>.diagdiff.
+func (todo *Todo) diffDiagnostics() {
+	var base *Configuration
+	for ci := range todo.Configurations {
+		c := &todo.Configurations[ci]
+		if len(c.diagnostics) == 0 {
+			continue
+		}
+		buf := new(bytes.Buffer)
+		for _, b := range todo.Benchmarks {
+			if ds, ok := c.diagnostics[b.Name]; ok {
+				fmt.Fprintf(buf, "# %s\n%s\n", b.Name, strings.Join(ds, "\n"))
+			}
+		}
+		file := c.thingBenchName("diag")
+		if err := ioutil.WriteFile(file, buf.Bytes(), 0664); err != nil {
+			fmt.Printf("There was an error writing %s, %v\n", file, err)
+		}
+		if base == nil {
+			base = c
+			continue
+		}
+
+		buf.Reset()
+		summary := ""
+		for _, b := range todo.Benchmarks {
+			if b.Disabled || base.buildFailed(b.Name) || c.buildFailed(b.Name) {
+				continue
+			}
+			added, removed := diffSorted(base.diagnostics[b.Name], c.diagnostics[b.Name])
+			if len(added) == 0 && len(removed) == 0 {
+				continue
+			}
+			fmt.Fprintf(buf, "# %s: +%d -%d\n", b.Name, len(added), len(removed))
+			for _, d := range removed {
+				fmt.Fprintf(buf, "- %s\n", d)
+			}
+			for _, d := range added {
+				fmt.Fprintf(buf, "+ %s\n", d)
+			}
+			summary += fmt.Sprintf("   %s: +%d -%d\n", b.Name, len(added), len(removed))
+		}
+		file = runBenchName(base.Name + "-vs-" + c.Name + ".diagdiff")
+		if err := ioutil.WriteFile(file, buf.Bytes(), 0664); err != nil {
+			fmt.Printf("There was an error writing %s, %v\n", file, err)
+			continue
+		}
+		if summary == "" {
+			fmt.Printf("Compiler diagnostics for %s are the same as for %s\n", c.Name, base.Name)
+		} else {
+			fmt.Printf("Compiler diagnostics for %s differ from %s, see %s:\n%s", c.Name, base.Name, file, summary)
+		}
+	}
+}
+
+// diffSorted returns the elements of sorted slice b not in sorted slice a,
+// and those of a not in b, counting repeated elements.
+func diffSorted(a, b []string) (added, removed []string) {
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			i++
+			j++
+		case a[i] < b[j]:
+			removed = append(removed, a[i])
+			i++
+		default:
+			added = append(added, b[j])
+			j++
+		}
+	}
+	return append(added, b[j:]...), append(removed, a[i:]...)
+}
@@ -0,0 +1,49 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRecordDiagnostics(t *testing.T) {
+	const gopath, dir = "/home/u/gopath", "/home/u/gopath/src/example.com/p"
+	tests := []struct {
+		name   string
+		root   string
+		output string
+		want   []string
+	}{
+		{"none", "", "# example.com/p\n", nil},
+		{"relative", "", `# example.com/p
+./main.go:19:4: can inline main.func1.deferwrap1
+./main.go:12:10: Found IsInBounds
+`, []string{
+			"example.com/p/main.go:12:10: Found IsInBounds",
+			"example.com/p/main.go:19:4: can inline main.func1.deferwrap1",
+		}},
+		{"GOROOT and module cache", "/usr/local/go", `# example.com/p
+/usr/local/go/src/sync/atomic/type.go:58:6: can inline atomic.(*Pointer[os.dirInfo]).Load
+/home/u/gopath/pkg/mod/golang.org/x/text@v0.3.0/width.go:7:6: can inline Lookup
+`, []string{
+			"golang.org/x/text@v0.3.0/width.go:7:6: can inline Lookup",
+			"sync/atomic/type.go:58:6: can inline atomic.(*Pointer[os.dirInfo]).Load",
+		}},
+		{"not diagnostics", "", `# example.com/p
+./main.go:16:2: 	regalloc	TIME(ns)	174280	main
+main.go: no such file
+ok  	example.com/p	0.01s
+`, nil},
+	}
+	for _, tt := range tests {
+		c := &Configuration{Root: tt.root}
+		b := &Benchmark{Name: "p"}
+		c.recordDiagnostics(b, gopath, dir, []byte(tt.output))
+		if got := c.diagnostics[b.Name]; !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: diagnostics = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// An executor runs test binaries that were built for a configuration's
+// Target somewhere other than the build host, for example on another
+// machine reached with ssh, or under an emulator.
+type executor interface {
+	// prepare makes the test binary bin (an absolute path on the build
+	// host) for configuration c available for running, e.g. by copying
+	// it to the target.  It is called once per binary, before any runs,
+	// and returns an error string if bin cannot be run.
+	prepare(c *Configuration, cwd, bin string) string
+	// command returns the command that runs bin for benchmark b with
+	// additional environment env and arguments args.  The caller
+	// adds the rest of the environment to any the command has.
+	command(b *Benchmark, bin string, env, args []string) *exec.Cmd
+}
+
+// A templateExecutor runs binaries with commands given as templates in
+// a configuration's Copy and Executor.  In each template element, {bin}
+// is replaced with the path of the binary on the build host, {name} with
+// the file name of the binary, {repo} with the benchmark's Repo (e.g. for
+// a directory on the target), {dir} with the benchmark's source directory
+// on the build host, {env} with the environment variables (VAR=value) to
+// set, and {args} with the arguments for the binary.
+// An element that is exactly {env} or {args} expands to one element per
+// variable or argument; otherwise they are quoted for a shell and joined
+// with spaces, as needed for a remote command run by ssh.
+type templateExecutor struct {
+	copy, run []string
+	gopath    string
+}
+
+func (e *templateExecutor) prepare(c *Configuration, cwd, bin string) string {
+	if len(e.copy) == 0 {
+		return ""
+	}
+	args := expandTemplate(e.copy, map[string][]string{"bin": {bin}, "name": {filepath.Base(bin)}})
+	s, _ := c.runBinary(cwd, exec.Command(args[0], args[1:]...), true, nil, nil, nil)
+	return s
+}
+
+func (e *templateExecutor) command(b *Benchmark, bin string, env, args []string) *exec.Cmd {
+	args = expandTemplate(e.run, map[string][]string{
+		"bin":  {bin},
+		"name": {filepath.Base(bin)},
+		"repo": {b.Repo},
+		"dir":  {e.gopath + "/src/" + b.Repo},
+		"env":  env,
+		"args": args,
+	})
+	return exec.Command(args[0], args[1:]...)
+}
+
+// expandTemplate returns the template t with the {key} values in vars substituted,
+// as described for templateExecutor.
+func expandTemplate(t []string, vars map[string][]string) []string {
+	var r []string
+	for _, s := range t {
+		if strings.HasPrefix(s, "{") && strings.HasSuffix(s, "}") {
+			if v, ok := vars[s[1:len(s)-1]]; ok {
+				r = append(r, v...)
+				continue
+			}
+		}
+		for k, v := range vars {
+			if !strings.Contains(s, "{"+k+"}") {
+				continue
+			}
+			q := make([]string, len(v))
+			for i, x := range v {
+				q[i] = shellQuote(x)
+			}
+			s = strings.Replace(s, "{"+k+"}", strings.Join(q, " "), -1)
+		}
+		r = append(r, s)
+	}
+	return r
+}
+
+// shellQuote returns s quoted as a single word for a POSIX shell.
+// Unlike escape, which is only for display, it is exact: unless s is made
+// only of characters that need no quoting, it is wrapped in single quotes,
+// and each single quote within it is written as a closing quote, an escaped
+// quote, and an opening quote.
+func shellQuote(s string) string {
+	if s != "" && strings.Trim(s, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_./=:,+@%") == "" {
+		return s
+	}
+	return "'" + strings.Replace(s, "'", `'\''`, -1) + "'"
+}
+
+// target returns the GOOS and GOARCH that c's binaries are built for,
+// which are the host's unless set by c's GcEnv or Target.
+func (c *Configuration) target() (goos, goarch string) {
+	goos, goarch = runtime.GOOS, runtime.GOARCH
+	if v := getenv(c.GcEnv, "GOOS"); v != "" {
+		goos = v
+	}
+	if v := getenv(c.GcEnv, "GOARCH"); v != "" {
+		goarch = v
+	}
+	if c.Target == "" {
+		return
+	}
+	if slash := strings.Index(c.Target, "/"); slash >= 0 {
+		return c.Target[:slash], c.Target[slash+1:]
+	}
+	return goos, c.Target
+}
+
+// targetEnv returns env with GOOS and GOARCH set for c's Target, if it has one.
+func (c *Configuration) targetEnv(env []string) []string {
+	if c.Target == "" {
+		return env
+	}
+	goos, goarch := c.target()
+	return replaceEnv(replaceEnv(env, "GOOS", goos), "GOARCH", goarch)
+}
+
+// A qemuExecutor runs binaries for another architecture on the build
+// host, with QEMU user-mode emulation (qemu-<arch>-static).
+type qemuExecutor struct {
+	qemu string
+}
+
+// qemuArch maps GOARCH values to the names QEMU uses for them.
+var qemuArch = map[string]string{
+	"386":      "i386",
+	"amd64":    "x86_64",
+	"arm":      "arm",
+	"arm64":    "aarch64",
+	"loong64":  "loongarch64",
+	"mips":     "mips",
+	"mipsle":   "mipsel",
+	"mips64":   "mips64",
+	"mips64le": "mips64el",
+	"ppc64":    "ppc64",
+	"ppc64le":  "ppc64le",
+	"riscv64":  "riscv64",
+	"s390x":    "s390x",
+}
+
+func (e *qemuExecutor) prepare(c *Configuration, cwd, bin string) string {
+	if _, err := exec.LookPath(e.qemu); err != nil {
+		return fmt.Sprintf("Cannot run %s for configuration %s, %v", bin, c.Name, err)
+	}
+	return ""
+}
+
+func (e *qemuExecutor) command(b *Benchmark, bin string, env, args []string) *exec.Cmd {
+	cmd := exec.Command(e.qemu, append([]string{bin}, args...)...)
+	cmd.Env = env
+	return cmd
+}
+
+// runsNatively maps GOARCH values to another GOARCH whose hosts
+// run their binaries without emulation.
+var runsNatively = map[string]string{
+	"386": "amd64",
+	"arm": "arm64",
+}
+
+// executor returns the executor for running c's binaries, or nil if
+// they run natively on the build host (perhaps in a container).
+// Unless c has an Executor, binaries for another architecture on
+// linux that the host cannot run natively are run with QEMU user-mode
+// emulation, in the container for sandboxed benchmarks.
+func (c *Configuration) executor(gopath string) executor {
+	if len(c.Executor) > 0 {
+		return &templateExecutor{copy: c.Copy, run: c.Executor, gopath: gopath}
+	}
+	goos, goarch := c.target()
+	if goarch == runtime.GOARCH || runsNatively[goarch] == runtime.GOARCH || goos != "linux" || runtime.GOOS != "linux" {
+		return nil
+	}
+	arch := qemuArch[goarch]
+	if arch == "" {
+		arch = goarch
+	}
+	return &qemuExecutor{qemu: "qemu-" + arch + "-static"}
+}
+
+// prepareTargets makes the binaries for each benchmark built for each
+// configuration with an executor available to it; a benchmark whose binary
+// could not be made available is not run for that configuration, and the
+// failure is passed to failed.
+func (todo *Todo) prepareTargets(cwd string, failed func(b *Benchmark, s string)) {
+	for ci := range todo.Configurations {
+		config := &todo.Configurations[ci]
+		x := config.executor(cwd + "/gopath")
+		if config.Disabled || x == nil {
+			continue
+		}
+		for bi := range todo.Benchmarks {
+			b := &todo.Benchmarks[bi]
+			if b.Disabled || config.buildFailed(b.Name) {
+				continue
+			}
+			if s := x.prepare(config, cwd, cwd+"/"+testBinDir+"/"+config.benchName(b)); s != "" {
+				fmt.Println(s)
+				if config.notBuilt == nil {
+					config.notBuilt = make(map[string]string)
+				}
+				config.notBuilt[b.Name] = "could not be made ready to run on the target"
+				failed(b, s+"("+b.Name+" in "+config.Name+")\n")
+			}
+		}
+	}
+}
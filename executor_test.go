@@ -0,0 +1,100 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestShellQuote(t *testing.T) {
+	tests := []struct {
+		s, want string
+	}{
+		{"", "''"},
+		{"-test.bench=.", "-test.bench=."},
+		{"GOROOT=/usr/local/go", "GOROOT=/usr/local/go"},
+		{"a b", "'a b'"},
+		{"$HOME", "'$HOME'"},
+		{"it's", `'it'\''s'`},
+		{`x"y`, `'x"y'`},
+		{`-test.run=^(TestA\d)$`, `'-test.run=^(TestA\d)$'`},
+		{"*", "'*'"},
+		{"a\nb", "'a\nb'"},
+	}
+	sh, err := exec.LookPath("sh")
+	for _, tt := range tests {
+		got := shellQuote(tt.s)
+		if got != tt.want {
+			t.Errorf("shellQuote(%q) = %q, want %q", tt.s, got, tt.want)
+		}
+		if err != nil {
+			continue
+		}
+		// The shell must see exactly tt.s.
+		out, err := exec.Command(sh, "-c", "printf %s "+got).Output()
+		if err != nil || string(out) != tt.s {
+			t.Errorf("sh -c 'printf %%s %s' printed %q, %v; want %q", got, out, err, tt.s)
+		}
+	}
+}
+
+func TestExpandTemplate(t *testing.T) {
+	vars := map[string][]string{
+		"bin":  {"/home/u/bent/testbin/Gonum_path_Tip"},
+		"name": {"Gonum_path_Tip"},
+		"repo": {"gonum.org/v1/gonum/graph/path"},
+		"env":  {"BENT_I=0", "GODEBUG=gctrace=1,x=y"},
+		"args": {"-test.run=^$", "-test.bench=Benchmark(A|B)"},
+	}
+	tests := []struct {
+		t, want []string
+	}{
+		{
+			[]string{"scp", "-q", "{bin}", "pi@box:bent/"},
+			[]string{"scp", "-q", "/home/u/bent/testbin/Gonum_path_Tip", "pi@box:bent/"},
+		},
+		{
+			[]string{"env", "{env}", "./{name}", "{args}"},
+			[]string{"env", "BENT_I=0", "GODEBUG=gctrace=1,x=y", "./Gonum_path_Tip", "-test.run=^$", "-test.bench=Benchmark(A|B)"},
+		},
+		{
+			[]string{"ssh", "pi@box", "cd bent/{repo} && env {env} ../{name} {args}"},
+			[]string{"ssh", "pi@box", "cd bent/gonum.org/v1/gonum/graph/path && env BENT_I=0 GODEBUG=gctrace=1,x=y ../Gonum_path_Tip '-test.run=^$' '-test.bench=Benchmark(A|B)'"},
+		},
+		{
+			[]string{"{unknown}", "a{unknown}b"},
+			[]string{"{unknown}", "a{unknown}b"},
+		},
+	}
+	for _, tt := range tests {
+		if got := expandTemplate(tt.t, vars); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("expandTemplate(%q):\ngot  %q\nwant %q", tt.t, got, tt.want)
+		}
+	}
+}
+
+func TestTarget(t *testing.T) {
+	tests := []struct {
+		c            Configuration
+		goos, goarch string
+	}{
+		{Configuration{Target: "linux/arm64"}, "linux", "arm64"},
+		{Configuration{Target: "riscv64", GcEnv: []string{"GOOS=freebsd"}}, "freebsd", "riscv64"},
+		{Configuration{GcEnv: []string{"GOOS=windows", "GOARCH=386"}}, "windows", "386"},
+	}
+	for _, tt := range tests {
+		goos, goarch := tt.c.target()
+		if goos != tt.goos || goarch != tt.goarch {
+			t.Errorf("%q %q: target %s/%s, want %s/%s", tt.c.Target, tt.c.GcEnv, goos, goarch, tt.goos, tt.goarch)
+		}
+		env := strings.Join(tt.c.targetEnv([]string{"GOOS=plan9"}), " ")
+		if tt.c.Target != "" && env != "GOOS="+tt.goos+" GOARCH="+tt.goarch {
+			t.Errorf("%q: targetEnv %s", tt.c.Target, env)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import "fmt"
+
+// A failurePolicy decides whether bent keeps going after a failure
+// to get, build, run an AfterBuild command for, or run a benchmark.
+// Failures of benchmarks with AllowFailure set are reported but
+// otherwise ignored: they do not count toward -max-failures, do
+// not trigger -fail-fast, and do not affect the exit code.
+type failurePolicy struct {
+	failFast    bool // stop at the first counted failure
+	maxFailures int  // if positive, stop after this many counted failures
+	keepBuilt   bool // if a benchmark fails to build in one configuration, still run it in the others (the default)
+	failures    int  // counted failures so far
+}
+
+var policy = failurePolicy{keepBuilt: true}
+
+// failed records a failure of benchmark b, which may be nil if the
+// failure is not specific to one benchmark, and reports whether bent
+// should stop now.
+func (p *failurePolicy) failed(b *Benchmark) bool {
+	if b != nil && b.AllowFailure {
+		return false
+	}
+	p.failures++
+	return p.failFast || p.maxFailures > 0 && p.failures >= p.maxFailures
+}
+
+// exitCode returns the exit code for bent given maxrc, the largest
+// exit code from running a (counted) benchmark.
+func (p *failurePolicy) exitCode(maxrc int) int {
+	if maxrc == 0 && p.failures > 0 {
+		return 1
+	}
+	return maxrc
+}
+
+// selected returns the (benchmark, configuration) pairs in todo
+// that are enabled, i.e., that bent intends to build and run.
+func (todo *Todo) selected() []pair {
+	var ps []pair
+	for ci, c := range todo.Configurations {
+		if c.Disabled {
+			continue
+		}
+		for bi, b := range todo.Benchmarks {
+			if !b.Disabled {
+				ps = append(ps, pair{b: bi, c: ci})
+			}
+		}
+	}
+	return ps
+}
+
+// reportSkipped prints each of the selected pairs that was
+// not built (and so was not run) because of a failure, and why.
+func (todo *Todo) reportSkipped(selected []pair) {
+	header := false
+	for _, p := range selected {
+		b, c := &todo.Benchmarks[p.b], &todo.Configurations[p.c]
+		why := c.skipReason
+		if why == "" {
+			why = c.notBuilt[b.Name]
+		}
+		if why == "" {
+			why = b.skipReason
+		}
+		if why == "" {
+			continue
+		}
+		if !header {
+			fmt.Println("Skipped benchmark/configuration pairs:")
+			header = true
+		}
+		fmt.Printf("   %s/%s: %s\n", b.Name, c.Name, why)
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// gctraceRE matches the line the runtime prints for each GC with
+// GODEBUG=gctrace=1, capturing the sweep termination and mark
+// termination (stop-the-world) clock times and the heap goal, e.g.
+// "gc 3 @0.012s 2%: 0.015+0.52+0.003 ms clock, 0.12+0.24/0.45/0.88+0.024 ms cpu, 4->4->0 MB, 5 MB goal, 8 P"
+var gctraceRE = regexp.MustCompile(`^gc \d+ @[0-9.]+s \d+%: ([0-9.]+)\+[0-9.]+\+([0-9.]+) ms clock, .* (\d+) MB goal`)
+
+// gcStats summarizes the GCs of one run of a test binary.
+type gcStats struct {
+	count    int
+	pauseNs  float64 // total stop-the-world time
+	peakGoal int64   // largest heap goal, bytes
+}
+
+// parseGCTrace summarizes the gctrace lines in output.
+func parseGCTrace(output []byte) gcStats {
+	var st gcStats
+	sc := bufio.NewScanner(bytes.NewReader(output))
+	for sc.Scan() {
+		m := gctraceRE.FindStringSubmatch(sc.Text())
+		if m == nil {
+			continue
+		}
+		st.count++
+		for _, ms := range m[1:3] {
+			if f, err := strconv.ParseFloat(ms, 64); err == nil {
+				st.pauseNs += f * 1e6
+			}
+		}
+		if mb, err := strconv.ParseInt(m[3], 10, 64); err == nil && mb<<20 > st.peakGoal {
+			st.peakGoal = mb << 20
+		}
+	}
+	return st
+}
+
+// gcLine returns the benchmark-format line reporting st for a run of
+// benchmark b.  GODEBUG=gctrace=1 reports every GC in the process, with
+// nothing to say which Go benchmark was running, so st sums the GCs of
+// all of them (and of test setup), and the line is per run of the binary.
+func gcLine(b *Benchmark, st gcStats) string {
+	return fmt.Sprintf("Benchmark%s 1 %d gcs/op %.0f gc-pause-ns/op %d peak-heap-goal-B/op\n",
+		strings.Title(b.Name), st.count, st.pauseNs, st.peakGoal)
+}
+
+// withGODEBUG returns env with setting added to its GODEBUG.
+func withGODEBUG(env []string, setting string) []string {
+	if old := getenv(env, "GODEBUG"); old != "" {
+		setting = old + "," + setting
+	}
+	return replaceEnv(env, "GODEBUG", setting)
+}
@@ -0,0 +1,35 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import "testing"
+
+func TestParseGCTrace(t *testing.T) {
+	tests := []struct {
+		name   string
+		stderr string
+		want   gcStats
+	}{
+		{"none", "", gcStats{}},
+		{"go1.19", `gc 1 @0.012s 2%: 0.015+0.52+0.003 ms clock, 0.12+0.24/0.45/0.88+0.024 ms cpu, 4->4->0 MB, 5 MB goal, 8 P
+gc 2 @0.020s 3%: 0.010+0.40+0.010 ms clock, 0.08+0.20/0.30/0.50+0.080 ms cpu, 4->5->1 MB, 12 MB goal, 8 P
+`, gcStats{count: 2, pauseNs: (0.015 + 0.003 + 0.010 + 0.010) * 1e6, peakGoal: 12 << 20}},
+		// From a test binary built with Go 1.22 and later, among other output.
+		{"go1.22", `=== RUN   TestA
+gc 1 @0.000s 30%: 0.011+0.60+0.017 ms clock, 0.011+0.53/0/0+0.017 ms cpu, 3->4->2 MB, 4 MB goal, 0 MB stacks, 0 MB globals, 1 P
+--- PASS: TestA (0.00s)
+gc 2 @0.002s 28%: 0.011+0.43+0.003 ms clock, 0.011+0.41/0/0+0.003 ms cpu, 5->6->2 MB, 6 MB goal, 0 MB stacks, 0 MB globals, 1 P (forced)
+PASS
+`, gcStats{count: 2, pauseNs: (0.011 + 0.017 + 0.011 + 0.003) * 1e6, peakGoal: 6 << 20}},
+		{"scavenger", "scvg: 0 MB released\ngc 1 @0.1s 1%: bad line\n", gcStats{}},
+	}
+	for _, tt := range tests {
+		got := parseGCTrace([]byte(tt.stderr))
+		// Allow for rounding in summing the pause times.
+		if got.count != tt.want.count || got.peakGoal != tt.want.peakGoal || got.pauseNs-tt.want.pauseNs > 1 || tt.want.pauseNs-got.pauseNs > 1 {
+			t.Errorf("%s: parseGCTrace = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
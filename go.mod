@@ -2,4 +2,8 @@ module github.com/dr2chase/bent
 
 go 1.15
 
-require github.com/BurntSushi/toml v0.3.1
+require (
+	github.com/BurntSushi/toml v0.3.1
+	github.com/google/pprof v0.0.0-20210720184732-4bb14d4b1be1
+	golang.org/x/sys v0.0.0-20191204072324-ce4227a45e2e
+)
@@ -0,0 +1,116 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// A Matrix describes a family of configurations as the cross product
+// of several axes.  Each axis is a list of partial configurations; one
+// configuration is generated for each combination of one element from
+// every non-empty axis, combined in the order Roots, GcFlags, RunEnv,
+// Wrappers.  The generated configuration's name joins the non-empty
+// names of its elements with "+", e.g. "Tip+Nl".  When combining, later
+// string values replace earlier ones, lists are concatenated, and the
+// result is disabled if any of its elements is.  Names may give some
+// generated configurations other names, e.g. to keep names used before
+// a family of configurations was written as a Matrix.
+type Matrix struct {
+	Extends  string            // Configuration supplying values not set by any axis
+	Roots    []Configuration   // Axis of Go roots, each element normally supplies Name and Root
+	GcFlags  []Configuration   // Axis of compiler flag sets
+	RunEnv   []Configuration   // Axis of run environment sets
+	Wrappers []Configuration   // Axis of run wrappers
+	Names    map[string]string // Generated names to replace, e.g. "Tip+Nl" = "TipNl"
+	file     string            // Where this matrix was read from, for error messages
+	line     int
+}
+
+// expandMatrices appends the configurations generated by each
+// Matrix in todo to todo.Configurations.
+func (todo *Todo) expandMatrices() error {
+	for _, m := range todo.Matrix {
+		cs, err := m.expand()
+		if err != nil {
+			return err
+		}
+		todo.Configurations = append(todo.Configurations, cs...)
+	}
+	todo.Matrix = nil
+	return nil
+}
+
+func (m *Matrix) expand() ([]Configuration, error) {
+	combos := []Configuration{{Extends: m.Extends, defined: make(map[string]bool), file: m.file, line: m.line}}
+	for _, axis := range [][]Configuration{m.Roots, m.GcFlags, m.RunEnv, m.Wrappers} {
+		if len(axis) == 0 {
+			continue
+		}
+		var next []Configuration
+		for _, c := range combos {
+			for i := range axis {
+				next = append(next, c.combine(&axis[i]))
+			}
+		}
+		combos = next
+	}
+	renamed := make(map[string]bool)
+	for i := range combos {
+		if name, ok := m.Names[combos[i].Name]; ok {
+			renamed[combos[i].Name] = true
+			combos[i].Name = name
+		}
+	}
+	for name := range m.Names {
+		if !renamed[name] {
+			return nil, fmt.Errorf("%s:%d: Matrix Names renames %s, which the matrix does not generate", m.file, m.line, name)
+		}
+	}
+	return combos, nil
+}
+
+// combine returns a copy of c with the values set in e added,
+// as described for Matrix.
+func (c Configuration) combine(e *Configuration) Configuration {
+	r := c
+	r.defined = make(map[string]bool)
+	for k := range c.defined {
+		r.defined[k] = true
+	}
+	switch {
+	case r.Name == "":
+		r.Name = e.Name
+	case e.Name != "":
+		r.Name += "+" + e.Name
+	}
+	r.Disabled = c.Disabled || e.Disabled
+
+	rv, ev := reflect.ValueOf(&r).Elem(), reflect.ValueOf(e).Elem()
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" { // unexported
+			continue
+		}
+		switch f.Name {
+		case "Name", "Extends", "Disabled":
+			continue
+		}
+		v := ev.Field(i)
+		if v.IsZero() {
+			continue
+		}
+		if v.Kind() == reflect.Slice {
+			old := rv.Field(i)
+			v = reflect.AppendSlice(reflect.AppendSlice(reflect.MakeSlice(v.Type(), 0, old.Len()+v.Len()), old), v)
+		}
+		rv.Field(i).Set(v)
+		r.defined[strings.ToLower(f.Name)] = true
+	}
+	return r
+}
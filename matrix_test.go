@@ -0,0 +1,155 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMatrix(t *testing.T) {
+	tests := []struct {
+		name string
+		toml string
+		want []string // describe of each configuration
+		err  string   // if not "", a substring of the expected error
+	}{
+		{"names", `
+[[Matrix]]
+  Roots = [ { Name = "Base", Root = "/go1" }, { Name = "Tip", Root = "/tip" } ]
+  GcFlags = [ { Name = "" }, { Name = "Nl", GcFlags = "-N -l", Disabled = true } ]
+`, []string{
+			`Base Root=/go1`,
+			`Base+Nl disabled Root=/go1 GcFlags="-N -l"`,
+			`Tip Root=/tip`,
+			`Tip+Nl disabled Root=/tip GcFlags="-N -l"`,
+		}, ""},
+		{"lists are concatenated", `
+[[Matrix]]
+  RunEnv = [ { Name = "a", RunEnv = ["A=1"] }, { Name = "b", RunEnv = ["B=1"] } ]
+  Wrappers = [ { Name = "", RunEnv = ["W=1"], AfterBuild = ["benchsize"] }, { Name = "prof", RunWrapper = ["cpuprofile"] } ]
+`, []string{
+			`a RunEnv=["A=1" "W=1"] AfterBuild=["benchsize"]`,
+			`a+prof RunEnv=["A=1"] RunWrapper=["cpuprofile"]`,
+			`b RunEnv=["B=1" "W=1"] AfterBuild=["benchsize"]`,
+			`b+prof RunEnv=["B=1"] RunWrapper=["cpuprofile"]`,
+		}, ""},
+		{"renames", `
+[[Matrix]]
+  Roots = [ { Name = "Base" }, { Name = "Tip" } ]
+  GcFlags = [ { Name = "" }, { Name = "l", GcFlags = "-l" } ]
+  Names = { "Base+l" = "Basel", "Tip+l" = "Tipl" }
+`, []string{
+			`Base`,
+			`Basel GcFlags="-l"`,
+			`Tip`,
+			`Tipl GcFlags="-l"`,
+		}, ""},
+		{"unused rename", `
+[[Matrix]]
+  Roots = [ { Name = "Base" }, { Name = "Tip" } ]
+  GcFlags = [ { Name = "" }, { Name = "l", GcFlags = "-l" } ]
+  Names = { "Base+Nl" = "BaseNl" }
+`, nil, "Matrix Names renames Base+Nl, which the matrix does not generate"},
+		{"matrix extends", `
+[[Configurations]]
+  Name = "Common"
+  GcFlags = "-d=ssa/check_bce"
+  RunEnv = ["GOGC=200"]
+  Disabled = true
+[[Matrix]]
+  Extends = "Common"
+  Roots = [ { Name = "Tip", Root = "/tip" } ]
+  GcFlags = [ { Name = "" }, { Name = "l", GcFlags = "-l" } ]
+`, []string{
+			`Common disabled GcFlags="-d=ssa/check_bce" RunEnv=["GOGC=200"]`,
+			`Tip Root=/tip GcFlags="-d=ssa/check_bce" RunEnv=["GOGC=200"]`,
+			`Tip+l Root=/tip GcFlags="-l" RunEnv=["GOGC=200"]`,
+		}, ""},
+		{"extends a renamed configuration", `
+[[Matrix]]
+  Roots = [ { Name = "Tip", Root = "/tip" } ]
+  Wrappers = [ { Name = "" }, { Name = "prof", RunWrapper = ["cpuprofile"], Disabled = true } ]
+  Names = { "Tip+prof" = "Tip-prof" }
+[[Configurations]]
+  Name = "Tip-prof-nopreempt"
+  Extends = "Tip-prof"
+  RunEnv = ["GODEBUG=asyncpreemptoff=1"]
+`, []string{
+			`Tip-prof-nopreempt Root=/tip RunEnv=["GODEBUG=asyncpreemptoff=1"] RunWrapper=["cpuprofile"]`,
+			`Tip Root=/tip`,
+			`Tip-prof disabled Root=/tip RunWrapper=["cpuprofile"]`,
+		}, ""},
+		{"extends a generated name", `
+[[Matrix]]
+  Roots = [ { Name = "Tip" } ]
+  Wrappers = [ { Name = "" }, { Name = "prof", RunWrapper = ["cpuprofile"] } ]
+  Names = { "Tip+prof" = "Tip-prof" }
+[[Configurations]]
+  Name = "X"
+  Extends = "Tip+prof"
+`, nil, "X extends Tip+prof, which does not exist"},
+	}
+	for _, tt := range tests {
+		todo, err := readTestTodo(t, map[string]string{"main.toml": tt.toml})
+		if tt.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("%s: error %v, want one containing %q", tt.name, err, tt.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		var got []string
+		for i := range todo.Configurations {
+			got = append(got, describe(&todo.Configurations[i]))
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s:\ngot  %q\nwant %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+// TestCronjobConfigurations checks that configurations-cronjob.toml
+// generates the configurations that cronjob.sh names.
+func TestCronjobConfigurations(t *testing.T) {
+	toml, err := ioutil.ReadFile("configurations-cronjob.toml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	todo, err := readTestTodo(t, map[string]string{"main.toml": string(toml)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, c := range todo.Configurations {
+		if !c.Disabled {
+			got = append(got, c.Name)
+		}
+	}
+	if want := []string{"Base", "Tip"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("enabled configurations %q, want %q", got, want)
+	}
+	script, err := ioutil.ReadFile("cronjob.sh")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"BaseNl", "TipNl", "Basel", "Tipl"} {
+		found := false
+		for _, c := range todo.Configurations {
+			found = found || c.Name == name
+		}
+		if !found {
+			t.Errorf("configurations-cronjob.toml does not generate %s", name)
+		}
+		if !strings.Contains(string(script), "${RUN}."+name+".stdout") {
+			t.Errorf("cronjob.sh does not read the results of %s", name)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// perfCounters counts hardware events (instructions, cycles, branch and
+// cache misses) for one run of a test binary, with -perf.  Only Linux
+// supports this, see perf_linux.go.
+type perfCounters struct {
+	fds []int // perf_event_open file descriptors, one for each of perfEvents
+}
+
+// perfLine returns the benchmark-format line reporting counts (from
+// perfCounters.stop) for the run of benchmark b, or "" if there are none,
+// e.g. "BenchmarkGonum_path 1 123 instructions/op 456 cycles/op ...".
+// The counters run from the start of the test binary to its exit, so when
+// b's Benchmarks pattern matches several Go benchmarks, the counts are
+// their total (plus test setup), not split among them; hence the line is
+// named for b, and "op" is one run of the binary.
+func perfLine(b *Benchmark, counts []uint64) string {
+	if len(counts) == 0 {
+		return ""
+	}
+	s := fmt.Sprintf("Benchmark%s 1", strings.Title(b.Name))
+	for i, c := range counts {
+		s += fmt.Sprintf(" %d %s", c, perfEvents[i].unit)
+	}
+	return s + "\n"
+}
+
+// perfUncounted returns the selected benchmark/configuration pairs whose
+// runs -perf cannot count, because they run sandboxed or by an executor,
+// and the number of selected pairs.
+func (todo *Todo) perfUncounted(gopath string) ([]string, int) {
+	var uncounted []string
+	selected := 0
+	for _, c := range todo.Configurations {
+		if c.Disabled {
+			continue
+		}
+		for _, b := range todo.Benchmarks {
+			if b.Disabled {
+				continue
+			}
+			selected++
+			if !b.NotSandboxed || c.executor(gopath) != nil {
+				uncounted = append(uncounted, b.Name+"/"+c.Name)
+			}
+		}
+	}
+	return uncounted, selected
+}
@@ -0,0 +1,101 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"golang.org/x/sys/unix"
+	"os/exec"
+	"runtime"
+	"syscall"
+	"unsafe"
+)
+
+// perfEvents are the hardware events counted with -perf, and the
+// units they are reported in.
+var perfEvents = []struct {
+	config uint64
+	unit   string
+}{
+	{unix.PERF_COUNT_HW_INSTRUCTIONS, "instructions/op"},
+	{unix.PERF_COUNT_HW_CPU_CYCLES, "cycles/op"},
+	{unix.PERF_COUNT_HW_BRANCH_MISSES, "branch-misses/op"},
+	{unix.PERF_COUNT_HW_CACHE_MISSES, "cache-misses/op"},
+}
+
+// perfUnavailable is set after the first failure to open a counter,
+// so that the failure is reported only once.
+var perfUnavailable error
+
+// start starts cmd with hardware counters for each of perfEvents attached
+// to it, counting in user mode, for all its threads and child processes.
+// So that nothing is missed, cmd is started under ptrace, which stops it
+// just after exec; the counters are opened, and then it is released.
+// If the counters cannot be opened, cmd still runs, but is not counted.
+func (p *perfCounters) start(cmd *exec.Cmd) error {
+	// Ptrace requests must come from the thread that started the process.
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Ptrace = true
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	pid := cmd.Process.Pid
+	var ws syscall.WaitStatus
+	if _, err := syscall.Wait4(pid, &ws, 0, nil); err != nil {
+		cmd.Process.Kill()
+		return err
+	}
+	if perfUnavailable == nil {
+		for _, e := range perfEvents {
+			attr := unix.PerfEventAttr{
+				Type:   unix.PERF_TYPE_HARDWARE,
+				Config: e.config,
+				Bits:   unix.PerfBitInherit | unix.PerfBitExcludeKernel | unix.PerfBitExcludeHv,
+			}
+			attr.Size = uint32(unsafe.Sizeof(attr))
+			fd, err := unix.PerfEventOpen(&attr, pid, -1, -1, unix.PERF_FLAG_FD_CLOEXEC)
+			if err != nil {
+				perfUnavailable = err
+				fmt.Printf("Cannot count hardware events with perf_event_open, %v; continuing without -perf\n", err)
+				p.close()
+				break
+			}
+			p.fds = append(p.fds, fd)
+		}
+	}
+	return syscall.PtraceDetach(pid)
+}
+
+// stop reads the counters, which should be after the process has exited,
+// and returns their values, or nil if nothing was counted.
+func (p *perfCounters) stop() []uint64 {
+	if len(p.fds) != len(perfEvents) {
+		p.close()
+		return nil
+	}
+	var counts []uint64
+	for _, fd := range p.fds {
+		var v uint64
+		if n, err := unix.Read(fd, (*[8]byte)(unsafe.Pointer(&v))[:]); err != nil || n != 8 {
+			p.close()
+			return nil
+		}
+		counts = append(counts, v)
+	}
+	p.close()
+	return counts
+}
+
+func (p *perfCounters) close() {
+	for _, fd := range p.fds {
+		unix.Close(fd)
+	}
+	p.fds = nil
+}
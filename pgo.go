@@ -0,0 +1,165 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// pgoSuffix is appended to the name of a configuration with PGO set
+// to name the derived configuration built with the collected profiles.
+const pgoSuffix = "+PGO"
+
+// addPGOConfigurations adds, just after each configuration with PGO set,
+// a derived configuration named <config>+PGO that is the same except
+// that its benchmarks are built with -pgo, using a CPU profile from
+// running the benchmark in the original configuration.  The derived
+// configuration shares the original's GOROOT and is built by buildPGO.
+func (todo *Todo) addPGOConfigurations() error {
+	var cs []Configuration
+	names := make(map[string]bool)
+	for _, c := range todo.Configurations {
+		names[c.Name] = true
+	}
+	for _, c := range todo.Configurations {
+		cs = append(cs, c)
+		if !c.PGO {
+			continue
+		}
+		d := c
+		d.Name += pgoSuffix
+		d.PGO = false
+		d.pgoFrom = c.Name
+		if names[d.Name] {
+			return fmt.Errorf("configuration %s has PGO set, but there is already a configuration named %s", c.Name, d.Name)
+		}
+		cs = append(cs, d)
+	}
+	todo.Configurations = cs
+	return nil
+}
+
+// selectPGOConfigurations adjusts the selection of configurations for
+// their +PGO configurations.  A +PGO configuration is selected with its
+// original configuration unless -c excludes it, and selecting a +PGO
+// configuration also selects its original, which provides its profiles.
+func (todo *Todo) selectPGOConfigurations(sel *selector) {
+	if sel == nil {
+		return
+	}
+	byName := make(map[string]*Configuration)
+	for i := range todo.Configurations {
+		byName[todo.Configurations[i].Name] = &todo.Configurations[i]
+	}
+	for i := range todo.Configurations {
+		d := &todo.Configurations[i]
+		c := byName[d.pgoFrom]
+		if c == nil {
+			continue
+		}
+		if d.Disabled && !c.Disabled && !sel.excludes(d.Name, d.Tags) {
+			d.Disabled = false
+		}
+		if !d.Disabled && c.Disabled {
+			fmt.Printf("Also running configuration %s, for the profiles to build %s\n", c.Name, d.Name)
+			c.Disabled = false
+		}
+	}
+}
+
+// pgoProfile returns the absolute name of the file holding the CPU profile
+// for benchmark b collected in the configuration named config.
+func pgoProfile(cwd, config string, b *Benchmark) string {
+	return cwd + "/" + benchDir + "/" + runstamp + "." + config + "." + b.Name + ".pgo"
+}
+
+// collectProfile runs the test binary for b in c N times (see -N), as for
+// c's results (see runCommand), with -test.cpuprofile, and merges the
+// profiles (see mergeProfileFiles) into the one for the <c>+PGO configuration.
+// The output of the runs is not part of c's results.
+// It returns an error string if a run or the merge failed.
+func (c *Configuration) collectProfile(b *Benchmark, cwd, container string) string {
+	profile := pgoProfile(cwd, c.Name, b)
+	var profiles []string
+	for i := 0; i < N; i++ {
+		p := fmt.Sprintf("%s.%d", profile, i)
+		cmd, _ := c.runCommand(b, cwd, container, i, benchDir, func(dir string) []string {
+			return []string{"-test.cpuprofile=" + dir + "/" + filepath.Base(p)}
+		}, nil)
+		if verbose > 0 {
+			fmt.Println(asCommandLine(cwd, cmd))
+		} else {
+			fmt.Print(".")
+		}
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			return fmt.Sprintf("There was an error collecting a profile for %s in %s, %v, output = %s", b.Name, c.Name, err, output)
+		}
+		if _, err := os.Stat(p); err != nil {
+			return fmt.Sprintf("Running %s in %s did not write a profile, %v", b.Name, c.Name, err)
+		}
+		profiles = append(profiles, p)
+	}
+	if s := c.mergeProfileFiles(cwd, profile, profiles); s != "" {
+		return fmt.Sprintf("There was an error merging the profiles for %s in %s, %s", b.Name, c.Name, s)
+	}
+	return ""
+}
+
+// buildPGO collects a merged CPU profile for each benchmark built in each
+// configuration with PGO set, and then builds each benchmark with its
+// profile (buildCount times) for the derived <config>+PGO configuration.
+// Sandboxed benchmarks are profiled in container; since the +PGO binaries
+// are built after it, runs in the container use the build host's testbin
+// (see runCommand).  Failures are passed to failed.
+func (todo *Todo) buildPGO(cwd, container string, buildCount int, failed func(b *Benchmark, s string)) {
+	for ci := range todo.Configurations {
+		d := &todo.Configurations[ci]
+		if d.pgoFrom == "" || d.Disabled {
+			continue
+		}
+		var c *Configuration
+		for i := range todo.Configurations {
+			if todo.Configurations[i].Name == d.pgoFrom {
+				c = &todo.Configurations[i]
+			}
+		}
+		if c.Disabled {
+			d.Disabled = true
+			d.skipReason = "configuration " + c.Name + " was not built"
+			continue
+		}
+		if verbose == 0 {
+			fmt.Print("\nProfiling and building " + d.Name)
+		}
+		for bi := range todo.Benchmarks {
+			b := &todo.Benchmarks[bi]
+			if b.Disabled {
+				continue
+			}
+			if d.notBuilt == nil {
+				d.notBuilt = make(map[string]string)
+			}
+			if c.buildFailed(b.Name) {
+				d.notBuilt[b.Name] = "failed to build in " + c.Name
+				continue
+			}
+			if s := c.collectProfile(b, cwd, container); s != "" {
+				fmt.Println(s)
+				d.notBuilt[b.Name] = "could not collect a profile in " + c.Name
+				failed(b, s+"\n")
+				continue
+			}
+			for k := 0; k < buildCount && !d.buildFailed(b.Name); k++ {
+				failed(b, d.compileOne(b, cwd, k))
+			}
+		}
+	}
+	if verbose == 0 {
+		fmt.Println()
+	}
+}
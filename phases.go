@@ -0,0 +1,81 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// phaseTimeRE matches the timing lines the compiler prints for each
+// SSA phase of each function when compiled with -d=ssa/all/time=1 (or
+// ssa/<phase>/time=1), for example
+//
+//	x.go:12:6: 	regalloc	TIME(ns)	12345	pkg.f
+var phaseTimeRE = regexp.MustCompile(`\t(\S+)\tTIME\(ns\)\t(\d+)\t`)
+
+// phaseTimes records the SSA phase times from one build, the output of
+// compileOne, for a configuration.  A benchmark's k'th build contributes
+// to the totals for build k, so that a configuration's .phases file
+// has one result per phase for each build (-a) requested.
+func (c *Configuration) phaseTimes(bench *Benchmark, output []byte) {
+	ns := make(map[string]int64)
+	for _, m := range phaseTimeRE.FindAllSubmatch(output, -1) {
+		t, err := strconv.ParseInt(string(m[2]), 10, 64)
+		if err != nil {
+			continue
+		}
+		ns[string(m[1])] += t
+	}
+	if len(ns) == 0 {
+		return
+	}
+	if c.phaseBuilds == nil {
+		c.phaseBuilds = make(map[string]int)
+	}
+	k := c.phaseBuilds[bench.Name]
+	c.phaseBuilds[bench.Name]++
+	for len(c.phases) <= k {
+		c.phases = append(c.phases, make(map[string]int64))
+	}
+	for p, t := range ns {
+		c.phases[k][p] += t
+	}
+}
+
+// writePhases writes the SSA phase times recorded for c, if any, to
+// <runstamp>.<config>.phases in benchmark format, one line per phase
+// per build, e.g. "BenchmarkPhase_regalloc 1 123456789 ns/op".
+func (c *Configuration) writePhases() {
+	if len(c.phases) == 0 {
+		return
+	}
+	file := c.thingBenchName("phases")
+	f, err := os.Create(file)
+	if err != nil {
+		fmt.Printf("There was an error creating %s, %v\n", file, err)
+		return
+	}
+	defer f.Close()
+	goos, goarch := c.target()
+	fmt.Fprintf(f, "goos: %s\n", goos)
+	fmt.Fprintf(f, "goarch: %s\n", goarch)
+	for _, ns := range c.phases {
+		var names []string
+		for p := range ns {
+			names = append(names, p)
+		}
+		sort.Strings(names)
+		for _, p := range names {
+			fmt.Fprintf(f, "BenchmarkPhase_%s 1 %d ns/op\n", p, ns[p])
+		}
+	}
+	if verbose > 0 {
+		fmt.Printf("SSA phase times for %s are in %s\n", c.Name, file)
+	}
+}
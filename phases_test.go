@@ -0,0 +1,47 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+// phaseOutput is part of the output of go test -c -gcflags=-d=ssa/all/time=1.
+const phaseOutput = `# example.com/p
+./main.go:19:17: 	short_circuit	TIME(ns)	743	main.func1.deferwrap1
+./main.go:19:17: 	opt	TIME(ns)	2232	main.func1.deferwrap1
+./main.go:20:4: 	opt	TIME(ns)	11305	main.func1
+./main.go:16:2: 	regalloc	TIME(ns)	174280	main
+./main.go:19:4: can inline main.func1.deferwrap1
+`
+
+func TestPhaseTimes(t *testing.T) {
+	tests := []struct {
+		name   string
+		builds []string // output of each build of one benchmark
+		want   []map[string]int64
+	}{
+		{"none", []string{"# example.com/p\n./main.go:19:4: can inline f\n"}, nil},
+		{"one build", []string{phaseOutput}, []map[string]int64{
+			{"short_circuit": 743, "opt": 2232 + 11305, "regalloc": 174280},
+		}},
+		{"two builds", []string{phaseOutput, "./x.go:1:6: \topt\tTIME(ns)\t5\tf\n"}, []map[string]int64{
+			{"short_circuit": 743, "opt": 2232 + 11305, "regalloc": 174280},
+			{"opt": 5},
+		}},
+		{"malformed", []string{"./x.go:1:6: \topt\tTIME(ns)\t99999999999999999999\tf\n./x.go:1:6: opt TIME(ns) 5 f\n"}, nil},
+	}
+	for _, tt := range tests {
+		c := &Configuration{}
+		b := &Benchmark{Name: "p"}
+		for _, out := range tt.builds {
+			c.phaseTimes(b, []byte(out))
+		}
+		if !reflect.DeepEqual(c.phases, tt.want) {
+			t.Errorf("%s: phases = %v, want %v", tt.name, c.phases, tt.want)
+		}
+	}
+}
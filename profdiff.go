@@ -0,0 +1,263 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"github.com/google/pprof/profile"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// funcTimes is the flat and cumulative time attributed to one function in a profile.
+type funcTimes struct {
+	flat, cum int64
+}
+
+// readProfile reads the profile in file.
+func readProfile(file string) (*profile.Profile, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return profile.Parse(f)
+}
+
+// cpuIndex returns the index of the CPU time sample value in p,
+// or of its last sample value if p has no CPU time.
+func cpuIndex(p *profile.Profile) int {
+	for i, st := range p.SampleType {
+		if st.Type == "cpu" {
+			return i
+		}
+	}
+	return len(p.SampleType) - 1
+}
+
+// byFunction returns the flat and cumulative CPU time of each function in p,
+// and the total time.  Inlined calls count as separate functions.
+func byFunction(p *profile.Profile) (map[string]*funcTimes, int64) {
+	vi := cpuIndex(p)
+	times := make(map[string]*funcTimes)
+	get := func(name string) *funcTimes {
+		t := times[name]
+		if t == nil {
+			t = &funcTimes{}
+			times[name] = t
+		}
+		return t
+	}
+	var total int64
+	for _, s := range p.Sample {
+		v := s.Value[vi]
+		total += v
+		seen := make(map[string]bool)
+		for li, loc := range s.Location {
+			for i, line := range loc.Line {
+				name := "?"
+				if line.Function != nil {
+					name = line.Function.Name
+				}
+				if li == 0 && i == 0 {
+					get(name).flat += v
+				}
+				if !seen[name] {
+					seen[name] = true
+					get(name).cum += v
+				}
+			}
+		}
+	}
+	return times, total
+}
+
+// diffProfile returns the difference p minus base, in the form
+// "pprof -diff_base" uses: base's samples are negated and labeled
+// pprof::base, and merged with p's.
+func diffProfile(base, p *profile.Profile) (*profile.Profile, error) {
+	base = base.Copy()
+	base.Scale(-1)
+	for _, s := range base.Sample {
+		if s.Label == nil {
+			s.Label = make(map[string][]string)
+		}
+		s.Label["pprof::base"] = []string{"true"}
+	}
+	return profile.Merge([]*profile.Profile{p, base})
+}
+
+// profileDelta writes to buf the top n functions in the profiles, from
+// configurations named baseName and name, ordered by the change in
+// flat time, and then those ordered by the change in cumulative time.
+func profileDelta(buf *bytes.Buffer, base, p *profile.Profile, baseName, name string, n int) {
+	bt, btotal := byFunction(base)
+	nt, ntotal := byFunction(p)
+	all := make(map[string]bool)
+	for f := range bt {
+		all[f] = true
+	}
+	for f := range nt {
+		all[f] = true
+	}
+	var fs []string
+	for f := range all {
+		fs = append(fs, f)
+	}
+	times := func(m map[string]*funcTimes, f string) funcTimes {
+		if t := m[f]; t != nil {
+			return *t
+		}
+		return funcTimes{}
+	}
+	abs := func(x int64) int64 {
+		if x < 0 {
+			return -x
+		}
+		return x
+	}
+	ms := func(ns int64) float64 { return float64(ns) / 1e6 }
+
+	fmt.Fprintf(buf, "Total: %s %.2fms, %s %.2fms, delta %+.2fms\n", baseName, ms(btotal), name, ms(ntotal), ms(ntotal-btotal))
+	for _, kind := range []string{"flat", "cum"} {
+		value := func(t funcTimes) int64 {
+			if kind == "flat" {
+				return t.flat
+			}
+			return t.cum
+		}
+		delta := func(f string) int64 { return value(times(nt, f)) - value(times(bt, f)) }
+		sort.Slice(fs, func(i, j int) bool {
+			di, dj := abs(delta(fs[i])), abs(delta(fs[j]))
+			if di != dj {
+				return di > dj
+			}
+			return fs[i] < fs[j]
+		})
+		fmt.Fprintf(buf, "\nTop %d by change in %s time (ms):\n", n, kind)
+		fmt.Fprintf(buf, "%12s %12s %12s  %s\n", baseName, name, "delta", "function")
+		for i, f := range fs {
+			if i >= n || delta(f) == 0 {
+				break
+			}
+			fmt.Fprintf(buf, "%12.2f %12.2f %+12.2f  %s\n", ms(value(times(bt, f))), ms(value(times(nt, f))), ms(delta(f)), f)
+		}
+	}
+}
+
+// latestProfileStamp returns the most recent runstamp with a profile directory.
+func latestProfileStamp() string {
+	dirs, _ := filepath.Glob(benchDir + "/*/profiles")
+	sort.Strings(dirs) // runstamps sort by time
+	if len(dirs) == 0 {
+		return ""
+	}
+	return filepath.Base(filepath.Dir(dirs[len(dirs)-1]))
+}
+
+// runConfigurations returns the names of the configurations that were run
+// with runstamp stamp, which each have a .stdout file in the bench directory.
+func runConfigurations(stamp string) []string {
+	files, _ := filepath.Glob(benchDir + "/" + stamp + ".*.stdout")
+	var names []string
+	for _, f := range files {
+		names = append(names, strings.TrimSuffix(strings.TrimPrefix(filepath.Base(f), stamp+"."), ".stdout"))
+	}
+	return names
+}
+
+// configOf returns the name of the configuration, among configs, whose
+// profile file (see profileName) is named file; because both configuration
+// and benchmark names may contain ".", this is the longest configuration
+// name that file starts with, followed by ".".  It returns "" if there is none.
+func configOf(file string, configs []string) string {
+	best := ""
+	for _, c := range configs {
+		if strings.HasPrefix(file, c+".") && len(c) > len(best) {
+			best = c
+		}
+	}
+	return best
+}
+
+// profdiffMain implements "bent profdiff [flags] base config", which
+// compares the merged CPU profiles of each benchmark in configurations
+// base and config from one run (see mergeProfiles), and returns the exit code.
+// For each benchmark it prints (and writes to a .txt file) the functions
+// whose time changed most, and writes a .pb.gz difference profile.
+func profdiffMain(args []string) int {
+	fs := flag.NewFlagSet("profdiff", flag.ExitOnError)
+	n := fs.Int("n", 20, "number of functions to list for each benchmark")
+	stamp := fs.String("stamp", "", "runstamp of the run whose profiles are compared (default is the latest)")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage of %s profdiff [flags] base-configuration configuration:\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return 2
+	}
+	baseName, name := fs.Arg(0), fs.Arg(1)
+	if *stamp == "" {
+		*stamp = latestProfileStamp()
+	}
+	dir := benchDir + "/" + *stamp + "/profiles"
+
+	configs := append(runConfigurations(*stamp), baseName, name)
+	baseFiles, _ := filepath.Glob(dir + "/" + baseName + ".*.cpu.pb.gz")
+	compared := 0
+	for _, bf := range baseFiles {
+		if configOf(filepath.Base(bf), configs) != baseName { // a longer configuration name, e.g. Tip.x
+			continue
+		}
+		bench := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(bf), baseName+"."), ".cpu.pb.gz")
+		nf := dir + "/" + name + "." + bench + ".cpu.pb.gz"
+		if _, err := os.Stat(nf); err != nil {
+			continue
+		}
+		base, err := readProfile(bf)
+		if err != nil {
+			fmt.Printf("There was an error reading %s, %v\n", bf, err)
+			return 1
+		}
+		p, err := readProfile(nf)
+		if err != nil {
+			fmt.Printf("There was an error reading %s, %v\n", nf, err)
+			return 1
+		}
+
+		out := dir + "/" + baseName + "-vs-" + name + "." + bench + ".cpu"
+		buf := new(bytes.Buffer)
+		fmt.Fprintf(buf, "# %s: %s vs %s\n", bench, baseName, name)
+		profileDelta(buf, base, p, baseName, name, *n)
+		if d, err := diffProfile(base, p); err != nil {
+			fmt.Fprintf(buf, "\nCould not make a difference profile, %v\n", err)
+		} else if f, err := os.Create(out + ".pb.gz"); err != nil {
+			fmt.Fprintf(buf, "\nCould not write a difference profile, %v\n", err)
+		} else {
+			err = d.Write(f)
+			f.Close()
+			if err == nil {
+				fmt.Fprintf(buf, "\nDifference profile in %s.pb.gz, or use go tool pprof -diff_base=%s %s\n", out, bf, nf)
+			}
+		}
+		fmt.Println(buf.String())
+		if err := ioutil.WriteFile(out+".txt", buf.Bytes(), 0664); err != nil {
+			fmt.Printf("There was an error writing %s.txt, %v\n", out, err)
+		}
+		compared++
+	}
+	if compared == 0 {
+		fmt.Printf("No benchmarks have merged CPU profiles for both %s and %s in %s\n", baseName, name, dir)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,105 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+)
+
+// profileFlags maps the kinds of profile that may be listed in a
+// configuration's Profile to the test binary flag that collects them.
+var profileFlags = map[string]string{
+	"cpu":   "-test.cpuprofile",
+	"mem":   "-test.memprofile",
+	"block": "-test.blockprofile",
+	"mutex": "-test.mutexprofile",
+}
+
+// profileDir returns the directory for this run's profiles,
+// bench/<runstamp>/profiles, relative to bent's working directory.
+func profileDir() string {
+	return benchDir + "/" + runstamp + "/profiles"
+}
+
+// profileName returns the file name, within the profile directory, of the
+// kind profile for iteration i of benchmark b in configuration c, or if i
+// is negative, of the profile merged from all iterations.
+func (c *Configuration) profileName(b *Benchmark, kind string, i int) string {
+	if i < 0 {
+		return c.Name + "." + b.Name + "." + kind + ".pb.gz"
+	}
+	return c.Name + "." + b.Name + "." + strconv.Itoa(i) + "." + kind + ".prof"
+}
+
+// profileArgs returns the flags for the test binary that collect the
+// profiles listed in c's Profile, and the execution trace if c's Trace is set,
+// for iteration i of benchmark b, into dir, which is the profile directory
+// as seen by the test binary.
+func (c *Configuration) profileArgs(b *Benchmark, i int, dir string) []string {
+	var args []string
+	for _, kind := range c.Profile {
+		if flag := profileFlags[kind]; flag != "" {
+			args = append(args, flag+"="+dir+"/"+c.profileName(b, kind, i))
+		}
+	}
+	if c.Trace {
+		args = append(args, "-test.trace="+dir+"/"+c.traceName(b, i))
+	}
+	return args
+}
+
+// mergeProfiles merges, for each benchmark in each configuration with a
+// Profile, the profiles from all iterations into one profile of each kind,
+// using "go tool pprof -proto" from the configuration's Go.
+func (todo *Todo) mergeProfiles(cwd string) {
+	dir := cwd + "/" + profileDir()
+	for _, c := range todo.Configurations {
+		if c.Disabled || len(c.Profile) == 0 {
+			continue
+		}
+		for bi := range todo.Benchmarks {
+			b := &todo.Benchmarks[bi]
+			if b.Disabled || c.buildFailed(b.Name) {
+				continue
+			}
+			for _, kind := range c.Profile {
+				var profiles []string
+				for i := 0; i < N; i++ {
+					p := dir + "/" + c.profileName(b, kind, i)
+					if _, err := os.Stat(p); err == nil {
+						profiles = append(profiles, p)
+					}
+				}
+				if len(profiles) == 0 {
+					continue
+				}
+				if s := c.mergeProfileFiles(cwd, dir+"/"+c.profileName(b, kind, -1), profiles); s != "" {
+					fmt.Printf("There was an error merging %s profiles for %s in %s, %s\n", kind, b.Name, c.Name, s)
+				}
+			}
+		}
+		fmt.Printf("Profiles for %s are in %s\n", c.Name, profileDir())
+	}
+}
+
+// mergeProfileFiles merges profiles into one profile, merged, using
+// "go tool pprof -proto" from c's Go.  It returns an error string if that fails.
+func (c *Configuration) mergeProfileFiles(cwd, merged string, profiles []string) string {
+	cmd := exec.Command(c.goCommand(), append([]string{"tool", "pprof", "-proto", "-output=" + merged}, profiles...)...)
+	cmd.Env = defaultEnv
+	if c.Root != "" {
+		cmd.Env = replaceEnv(cmd.Env, "GOROOT", c.Root)
+	}
+	if verbose > 0 {
+		fmt.Println(asCommandLine(cwd, cmd))
+	}
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Sprintf("%v, output = %s", err, output)
+	}
+	return ""
+}
@@ -0,0 +1,42 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestProfileArgs(t *testing.T) {
+	b := &Benchmark{Name: "Gonum_path"}
+	tests := []struct {
+		c    Configuration
+		i    int
+		want []string
+	}{
+		{Configuration{Name: "Tip"}, 0, nil},
+		{Configuration{Name: "Tip", Profile: []string{"cpu"}}, 0, []string{
+			"-test.cpuprofile=/p/Tip.Gonum_path.0.cpu.prof",
+		}},
+		{Configuration{Name: "Base", Profile: []string{"mem", "block", "mutex"}}, 2, []string{
+			"-test.memprofile=/p/Base.Gonum_path.2.mem.prof",
+			"-test.blockprofile=/p/Base.Gonum_path.2.block.prof",
+			"-test.mutexprofile=/p/Base.Gonum_path.2.mutex.prof",
+		}},
+		{Configuration{Name: "Tip", Profile: []string{"cpu"}, Trace: true}, 1, []string{
+			"-test.cpuprofile=/p/Tip.Gonum_path.1.cpu.prof",
+			"-test.trace=/p/Tip.Gonum_path.1.trace",
+		}},
+	}
+	for _, tt := range tests {
+		if got := tt.c.profileArgs(b, tt.i, "/p"); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s %v, run %d: profileArgs = %q, want %q", tt.c.Name, tt.c.Profile, tt.i, got, tt.want)
+		}
+	}
+	c := &Configuration{Name: "Tip"}
+	if got, want := c.profileName(b, "cpu", -1), "Tip.Gonum_path.cpu.pb.gz"; got != want {
+		t.Errorf("merged profileName = %q, want %q", got, want)
+	}
+}
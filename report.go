@@ -0,0 +1,467 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"html"
+	"html/template"
+	"io/ioutil"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// samples holds the values of one unit from benchmark-format results,
+// by benchmark and then configuration.
+type samples map[string]map[string][]float64
+
+// readResults adds the benchmark-format lines in file, from configuration
+// config, to results, by unit.  Benchmark names are qualified by the last
+// element of the most recent "pkg:" line, since benchmarks in different
+// test binaries may have the same name.
+func readResults(file, config string, results map[string]samples) {
+	f, err := os.Open(file)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	pkg := ""
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		line := sc.Text()
+		if strings.HasPrefix(line, "pkg:") {
+			pkg = strings.TrimSpace(strings.TrimPrefix(line, "pkg:"))
+			pkg = pkg[strings.LastIndex(pkg, "/")+1:]
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 4 || len(fields)%2 != 0 || !strings.HasPrefix(fields[0], "Benchmark") {
+			continue
+		}
+		if _, err := strconv.Atoi(fields[1]); err != nil {
+			continue
+		}
+		name := strings.TrimPrefix(fields[0], "Benchmark")
+		if pkg != "" {
+			name = pkg + "." + name
+		}
+		for i := 2; i+1 < len(fields); i += 2 {
+			v, err := strconv.ParseFloat(fields[i], 64)
+			if err != nil {
+				continue
+			}
+			unit := fields[i+1]
+			if results[unit] == nil {
+				results[unit] = make(samples)
+			}
+			if results[unit][name] == nil {
+				results[unit][name] = make(map[string][]float64)
+			}
+			results[unit][name][config] = append(results[unit][name][config], v)
+		}
+	}
+}
+
+// median returns the median of xs, which it sorts.
+func median(xs []float64) float64 {
+	sort.Float64s(xs)
+	n := len(xs)
+	if n%2 == 1 {
+		return xs[n/2]
+	}
+	return (xs[n/2-1] + xs[n/2]) / 2
+}
+
+// delta is the change in one benchmark's results from the base configuration.
+type delta struct {
+	Benchmark, Config string
+	Base, Value       float64 // medians
+	Pct, Lo, Hi       float64 // change and 95% confidence interval, in percent
+	HasCI             bool
+}
+
+// Significant reports whether d's confidence interval excludes no change.
+func (d delta) Significant() bool {
+	return d.HasCI && (d.Lo > 0 || d.Hi < 0)
+}
+
+// compare returns the change in median from base to x, with a 95% confidence
+// interval from a bootstrap of the ratio of medians if both have at least
+// two samples.  The bootstrap uses a fixed seed, so reports are reproducible.
+func compare(base, x []float64) (pct, lo, hi float64, ok bool) {
+	mb, mx := median(append([]float64(nil), base...)), median(append([]float64(nil), x...))
+	if mb == 0 {
+		return 0, 0, 0, false
+	}
+	pct = 100 * (mx/mb - 1)
+	if len(base) < 2 || len(x) < 2 {
+		return pct, pct, pct, false
+	}
+	r := rand.New(rand.NewSource(1))
+	resample := func(xs, into []float64) float64 {
+		for i := range into {
+			into[i] = xs[r.Intn(len(xs))]
+		}
+		return median(into)
+	}
+	const n = 1000
+	rb, rx := make([]float64, len(base)), make([]float64, len(x))
+	var ratios []float64
+	for i := 0; i < n; i++ {
+		if b := resample(base, rb); b != 0 {
+			ratios = append(ratios, 100*(resample(x, rx)/b-1))
+		}
+	}
+	sort.Float64s(ratios)
+	return pct, ratios[len(ratios)*25/1000], ratios[len(ratios)*975/1000], true
+}
+
+// panel is one chart in a report: the changes in one unit from the base configuration.
+type panel struct {
+	Unit    string
+	Deltas  []delta
+	Geomean map[string]float64 // percent change, by configuration
+	SVG     template.HTML
+}
+
+// makePanel returns the panel comparing the results in unit for each
+// configuration in configs to those for base, or nil if there are none.
+func makePanel(unit string, s samples, base string, configs []string) *panel {
+	p := &panel{Unit: unit, Geomean: make(map[string]float64)}
+	var names []string
+	for name := range s {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	logSum, count := make(map[string]float64), make(map[string]int)
+	for _, name := range names {
+		b := s[name][base]
+		if len(b) == 0 {
+			continue
+		}
+		for _, c := range configs {
+			x := s[name][c]
+			if c == base || len(x) == 0 {
+				continue
+			}
+			d := delta{Benchmark: name, Config: c}
+			d.Base, d.Value = median(b), median(x)
+			if d.Base == 0 {
+				continue
+			}
+			d.Pct, d.Lo, d.Hi, d.HasCI = compare(b, x)
+			p.Deltas = append(p.Deltas, d)
+			if d.Base > 0 && d.Value > 0 {
+				logSum[c] += math.Log(d.Value / d.Base)
+				count[c]++
+			}
+		}
+	}
+	if len(p.Deltas) == 0 {
+		return nil
+	}
+	for c, n := range count {
+		p.Geomean[c] = 100 * (math.Exp(logSum[c]/float64(n)) - 1)
+	}
+	p.SVG = p.chart(configs, base)
+	return p
+}
+
+// chartColors are the colors of the bars for successive configurations.
+var chartColors = []string{"#4e79a7", "#f28e2b", "#59a14f", "#e15759", "#b07aa1", "#76b7b2", "#edc948", "#9c755f"}
+
+// chart returns an SVG bar chart of p's changes, one row per benchmark and
+// one bar per configuration, with whiskers for the confidence intervals.
+func (p *panel) chart(configs []string, base string) template.HTML {
+	var others []string
+	for _, c := range configs {
+		if c != base {
+			others = append(others, c)
+		}
+	}
+	color := make(map[string]string)
+	for i, c := range others {
+		color[c] = chartColors[i%len(chartColors)]
+	}
+	var rows []string
+	byRow := make(map[string][]delta)
+	for _, d := range p.Deltas {
+		if byRow[d.Benchmark] == nil {
+			rows = append(rows, d.Benchmark)
+		}
+		byRow[d.Benchmark] = append(byRow[d.Benchmark], d)
+	}
+	scale := 1.0 // percent at the edge of the chart
+	for _, d := range p.Deltas {
+		scale = math.Max(scale, math.Max(math.Abs(d.Lo), math.Abs(d.Hi)))
+		scale = math.Max(scale, math.Abs(d.Pct))
+	}
+	scale = math.Min(scale*1.1, 1000)
+
+	const label, width, bar = 280, 440, 10
+	rowHeight := len(others)*bar + 8
+	height := len(rows)*rowHeight + 40
+	mid := label + width/2
+	x := func(pct float64) float64 {
+		pct = math.Max(-scale, math.Min(scale, pct))
+		return float64(mid) + pct/scale*width/2
+	}
+	buf := new(bytes.Buffer)
+	fmt.Fprintf(buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" font-family="sans-serif" font-size="11">`+"\n", label+width+20, height)
+	for _, pct := range []float64{-scale, -scale / 2, 0, scale / 2, scale} {
+		fmt.Fprintf(buf, `<line x1="%.1f" y1="20" x2="%.1f" y2="%d" stroke="#ddd"/>`, x(pct), x(pct), height-15)
+		fmt.Fprintf(buf, `<text x="%.1f" y="%d" text-anchor="middle">%+.1f%%</text>`+"\n", x(pct), height-3, pct)
+	}
+	for i, c := range others {
+		fmt.Fprintf(buf, `<rect x="%d" y="4" width="10" height="10" fill="%s"/><text x="%d" y="13">%s vs %s</text>`+"\n",
+			label+i*140, color[c], label+i*140+14, html.EscapeString(c), html.EscapeString(base))
+	}
+	for r, name := range rows {
+		y := 22 + r*rowHeight
+		fmt.Fprintf(buf, `<text x="%d" y="%d" text-anchor="end">%s</text>`+"\n", label-6, y+rowHeight/2+3, html.EscapeString(name))
+		for _, d := range byRow[name] {
+			i := 0
+			for i < len(others) && others[i] != d.Config {
+				i++
+			}
+			by := y + 4 + i*bar
+			x0, x1 := math.Min(x(0), x(d.Pct)), math.Max(x(0), x(d.Pct))
+			opacity := "0.45"
+			if d.Significant() {
+				opacity = "1"
+			}
+			fmt.Fprintf(buf, `<rect x="%.1f" y="%d" width="%.1f" height="%d" fill="%s" fill-opacity="%s"><title>%s %s: %+.2f%% [%+.2f%%, %+.2f%%]</title></rect>`,
+				x0, by, math.Max(x1-x0, 1), bar-2, color[d.Config], opacity, html.EscapeString(name), html.EscapeString(d.Config), d.Pct, d.Lo, d.Hi)
+			if d.HasCI {
+				cy := by + (bar-2)/2
+				fmt.Fprintf(buf, `<line x1="%.1f" y1="%d" x2="%.1f" y2="%d" stroke="#333"/>`, x(d.Lo), cy, x(d.Hi), cy)
+				fmt.Fprintf(buf, `<line x1="%.1f" y1="%d" x2="%.1f" y2="%d" stroke="#333"/>`, x(d.Lo), by, x(d.Lo), by+bar-2)
+				fmt.Fprintf(buf, `<line x1="%.1f" y1="%d" x2="%.1f" y2="%d" stroke="#333"/>`, x(d.Hi), by, x(d.Hi), by+bar-2)
+			}
+			buf.WriteString("\n")
+		}
+	}
+	fmt.Fprintf(buf, `<line x1="%d" y1="20" x2="%d" y2="%d" stroke="#000"/>`+"\n</svg>", mid, mid, height-15)
+	return template.HTML(buf.String())
+}
+
+// reportData is what the report template shows.
+type reportData struct {
+	Runstamp string
+	Base     string
+	Configs  []string
+	Sections []section
+	Files    []link
+}
+
+// section is a group of panels in a report, e.g. for build times.
+type section struct {
+	Title  string
+	Panels []*panel
+}
+
+// link is a result, log, or profile file to link to from a report.
+type link struct {
+	Name, Href string
+}
+
+var reportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>bent {{.Runstamp}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; font-size: 12px; margin: 1em 0; }
+td, th { border: 1px solid #ccc; padding: 2px 6px; text-align: right; }
+td:first-child, th:first-child { text-align: left; }
+.sig { font-weight: bold; }
+details { margin-bottom: 2em; }
+</style>
+</head>
+<body>
+<h1>bent run {{.Runstamp}}</h1>
+<p>Changes in the median of each configuration from {{.Base}}, with 95% confidence intervals
+(bootstrap); faded bars are not significant.  Configurations: {{range $i, $c := .Configs}}{{if $i}}, {{end}}{{$c}}{{end}}.</p>
+{{range .Sections}}
+<h2>{{.Title}}</h2>
+{{range .Panels}}
+<h3>{{.Unit}}</h3>
+<p>Geomean: {{range $c, $g := .Geomean}}{{$c}} {{printf "%+.2f%%" $g}} &nbsp; {{end}}</p>
+{{.SVG}}
+<details><summary>Table</summary>
+<table>
+<tr><th>benchmark</th><th>configuration</th><th>{{$.Base}}</th><th>value</th><th>delta</th><th>95% CI</th></tr>
+{{range .Deltas}}<tr{{if .Significant}} class="sig"{{end}}><td>{{.Benchmark}}</td><td>{{.Config}}</td><td>{{printf "%.4g" .Base}}</td><td>{{printf "%.4g" .Value}}</td><td>{{printf "%+.2f%%" .Pct}}</td><td>{{if .HasCI}}{{printf "[%+.2f%%, %+.2f%%]" .Lo .Hi}}{{end}}</td></tr>
+{{end}}</table>
+</details>
+{{end}}
+{{end}}
+<h2>Files</h2>
+<ul>
+{{range .Files}}<li><a href="{{.Href}}">{{.Name}}</a></li>
+{{end}}</ul>
+</body>
+</html>
+`))
+
+// latestRunstamp returns the most recent runstamp with results in the bench directory.
+func latestRunstamp() string {
+	files, _ := filepath.Glob(benchDir + "/*.stdout")
+	latest := ""
+	for _, f := range files {
+		if s := strings.SplitN(filepath.Base(f), ".", 2)[0]; s > latest {
+			latest = s
+		}
+	}
+	return latest
+}
+
+// reportMain implements "bent report [flags]", which summarizes the results
+// of one run: for each unit in the benchmark results, build times, and
+// binary sizes, the change from a base configuration in each benchmark.
+// With -html it writes a self-contained HTML page with charts and links to
+// the run's other files.  It returns the exit code.
+func reportMain(args []string) int {
+	fs := flag.NewFlagSet("report", flag.ExitOnError)
+	asHTML := fs.Bool("html", false, "write an HTML report with charts (default is a text summary)")
+	stamp := fs.String("stamp", "", "runstamp of the run to report (default is the latest)")
+	base := fs.String("base", "", "configuration to compare the others to (default is the first, by name)")
+	out := fs.String("o", "", "file for the HTML report (default bench/<runstamp>.report.html)")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage of %s report [flags]:\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if fs.NArg() != 0 {
+		fs.Usage()
+		return 2
+	}
+	if *stamp == "" {
+		*stamp = latestRunstamp()
+	}
+	prefix := benchDir + "/" + *stamp + "."
+	stdouts, _ := filepath.Glob(prefix + "*.stdout")
+	var configs []string
+	for _, f := range stdouts {
+		configs = append(configs, strings.TrimSuffix(strings.TrimPrefix(f, prefix), ".stdout"))
+	}
+	sort.Strings(configs)
+	if len(configs) == 0 {
+		fmt.Printf("There are no results for runstamp %q in %s\n", *stamp, benchDir)
+		return 1
+	}
+	if *base == "" {
+		*base = configs[0]
+	}
+	found := false
+	for _, c := range configs {
+		found = found || c == *base
+	}
+	if !found {
+		fmt.Printf("There are no results for configuration %s in run %s, only for %s\n", *base, *stamp, strings.Join(configs, ", "))
+		return 1
+	}
+
+	// Read each kind of result, and make a panel for each unit of interest.
+	data := reportData{Runstamp: *stamp, Base: *base, Configs: configs}
+	for _, kind := range []struct {
+		title, suffix string
+		units         []string // in this order; nil means all, with ns/op first
+	}{
+		{"Benchmarks", "stdout", nil},
+		{"Build time", "build", []string{"build-real-ns/op", "build-user-ns/op"}},
+		{"Binary size", "benchsize", []string{"total-bytes", "text-bytes"}},
+	} {
+		results := make(map[string]samples)
+		for _, c := range configs {
+			readResults(prefix+c+"."+kind.suffix, c, results)
+		}
+		units := kind.units
+		if units == nil {
+			for u := range results {
+				units = append(units, u)
+			}
+			sort.Slice(units, func(i, j int) bool {
+				if (units[i] == "ns/op") != (units[j] == "ns/op") {
+					return units[i] == "ns/op"
+				}
+				return units[i] < units[j]
+			})
+		}
+		s := section{Title: kind.title}
+		for _, u := range units {
+			if p := makePanel(u, results[u], *base, configs); p != nil {
+				s.Panels = append(s.Panels, p)
+			}
+		}
+		if len(s.Panels) > 0 {
+			data.Sections = append(data.Sections, s)
+		}
+	}
+
+	if !*asHTML {
+		for _, s := range data.Sections {
+			for _, p := range s.Panels {
+				fmt.Printf("\n%s, %s (vs %s)\n", s.Title, p.Unit, *base)
+				for _, d := range p.Deltas {
+					ci := ""
+					if d.HasCI {
+						ci = fmt.Sprintf("[%+.2f%%, %+.2f%%]", d.Lo, d.Hi)
+						if d.Significant() {
+							ci += " *"
+						}
+					}
+					fmt.Printf("  %-40s %-16s %12.4g %12.4g %+8.2f%% %s\n", d.Benchmark, d.Config, d.Base, d.Value, d.Pct, ci)
+				}
+				for _, c := range configs {
+					if g, ok := p.Geomean[c]; ok {
+						fmt.Printf("  %-40s %-16s %12s %12s %+8.2f%%\n", "[Geo mean]", c, "", "", g)
+					}
+				}
+			}
+		}
+		return 0
+	}
+
+	// Links are relative to the bench directory, where the report goes by default.
+	if *out == "" {
+		*out = prefix + "report.html"
+	}
+	for _, f := range resultFiles(*stamp) {
+		if f != *out {
+			rel := strings.TrimPrefix(f, benchDir+"/")
+			data.Files = append(data.Files, link{Name: rel, Href: rel})
+		}
+	}
+	if dir, _ := filepath.Abs(filepath.Dir(*out)); dir != "" {
+		if bd, _ := filepath.Abs(benchDir); bd != dir {
+			for i := range data.Files {
+				if rel, err := filepath.Rel(dir, filepath.Join(bd, data.Files[i].Href)); err == nil {
+					data.Files[i].Href = rel
+				}
+			}
+		}
+	}
+	buf := new(bytes.Buffer)
+	if err := reportTemplate.Execute(buf, &data); err != nil {
+		fmt.Printf("There was an error making the report, %v\n", err)
+		return 1
+	}
+	if err := ioutil.WriteFile(*out, buf.Bytes(), 0664); err != nil {
+		fmt.Printf("There was an error writing %s, %v\n", *out, err)
+		return 1
+	}
+	fmt.Printf("Report for %s is in %s\n", *stamp, *out)
+	return 0
+}
@@ -0,0 +1,137 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"io/ioutil"
+	"math"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestReadResults(t *testing.T) {
+	tests := []struct {
+		name, text string
+		want       map[string]samples
+	}{
+		{"qualified by pkg", `
+goos: linux
+pkg: gonum.org/v1/gonum/graph/path
+BenchmarkDijkstra-8   	     100	  12345 ns/op	    2048 B/op	      12 allocs/op
+BenchmarkDijkstra-8   	     100	  12000 ns/op	    2048 B/op	      12 allocs/op
+pkg: github.com/ethereum/go-ethereum/common/bitutil
+BenchmarkDijkstra-8   	     200	   5000 ns/op
+PASS
+`, map[string]samples{
+			"ns/op": {
+				"path.Dijkstra-8":    {"Tip": {12345, 12000}},
+				"bitutil.Dijkstra-8": {"Tip": {5000}},
+			},
+			"B/op":      {"path.Dijkstra-8": {"Tip": {2048, 2048}}},
+			"allocs/op": {"path.Dijkstra-8": {"Tip": {12, 12}}},
+		}},
+		{"no pkg", `
+BenchmarkBuild 1 3.5e9 build-real-ns/op 2.1e9 build-user-ns/op
+`, map[string]samples{
+			"build-real-ns/op": {"Build": {"Tip": {3.5e9}}},
+			"build-user-ns/op": {"Build": {"Tip": {2.1e9}}},
+		}},
+		{"not results", `
+Benchmark
+BenchmarkA 1 2
+BenchmarkA x 2 ns/op
+BenchmarkA 1 2 ns/op 3
+Benchmarks are fun, they say
+BenchmarkA 1 y ns/op 4 B/op
+--- FAIL: BenchmarkA
+`, map[string]samples{
+			"B/op": {"A": {"Tip": {4}}},
+		}},
+	}
+	for _, tt := range tests {
+		file := filepath.Join(t.TempDir(), "results")
+		if err := ioutil.WriteFile(file, []byte(tt.text), 0664); err != nil {
+			t.Fatal(err)
+		}
+		got := make(map[string]samples)
+		readResults(file, "Tip", got)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s:\ngot  %v\nwant %v", tt.name, got, tt.want)
+		}
+	}
+
+	// A missing file adds nothing.
+	got := make(map[string]samples)
+	readResults(filepath.Join(t.TempDir(), "missing"), "Tip", got)
+	if len(got) != 0 {
+		t.Errorf("missing file: got %v", got)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name        string
+		base, x     []float64
+		pct         float64
+		ok          bool
+		significant bool
+	}{
+		{"zero base", []float64{0, 0}, []float64{1, 2}, 0, false, false},
+		{"one sample", []float64{10}, []float64{12, 12}, 20, false, false},
+		{"same", []float64{10, 10, 10}, []float64{10, 10, 10}, 0, true, false},
+		{"faster", []float64{100, 101, 99, 100, 102}, []float64{80, 81, 79, 80, 82}, -20, true, true},
+		{"slower", []float64{100, 101, 99, 100}, []float64{110, 111, 109, 110}, 10, true, true},
+		{"noise", []float64{90, 110, 100, 95, 105}, []float64{92, 108, 101, 96, 104}, 1, true, false},
+	}
+	for _, tt := range tests {
+		pct, lo, hi, ok := compare(tt.base, tt.x)
+		if math.Abs(pct-tt.pct) > 1e-9 || ok != tt.ok {
+			t.Errorf("%s: compare = %v, _, _, %v; want %v, _, _, %v", tt.name, pct, ok, tt.pct, tt.ok)
+			continue
+		}
+		if !ok && (lo != pct || hi != pct) {
+			t.Errorf("%s: without a confidence interval, got [%v, %v], want [%v, %v]", tt.name, lo, hi, pct, pct)
+		}
+		if lo > pct || hi < pct {
+			t.Errorf("%s: confidence interval [%v, %v] does not contain %v", tt.name, lo, hi, pct)
+		}
+		d := delta{Pct: pct, Lo: lo, Hi: hi, HasCI: ok}
+		if d.Significant() != tt.significant {
+			t.Errorf("%s: [%v, %v] significant = %v, want %v", tt.name, lo, hi, d.Significant(), tt.significant)
+		}
+		// The bootstrap is seeded, so reports are reproducible.
+		if pct2, lo2, hi2, _ := compare(tt.base, tt.x); pct2 != pct || lo2 != lo || hi2 != hi {
+			t.Errorf("%s: second compare = %v [%v, %v], first %v [%v, %v]", tt.name, pct2, lo2, hi2, pct, lo, hi)
+		}
+	}
+}
+
+func TestMakePanel(t *testing.T) {
+	s := samples{
+		"A": {"Base": {100, 100}, "Tip": {50, 50}, "Other": {100}},
+		"B": {"Base": {100, 100}, "Tip": {200, 200}},
+		"C": {"Tip": {1, 2}},              // no base, so no comparison
+		"D": {"Base": {0, 0}, "Tip": {1}}, // zero base, likewise
+	}
+	p := makePanel("ns/op", s, "Base", []string{"Base", "Other", "Tip"})
+	var got []string
+	for _, d := range p.Deltas {
+		got = append(got, d.Benchmark+"/"+d.Config)
+	}
+	if want := []string{"A/Other", "A/Tip", "B/Tip"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("deltas %q, want %q", got, want)
+	}
+	// Halving one and doubling another is no change overall.
+	if g := p.Geomean["Tip"]; math.Abs(g) > 1e-9 {
+		t.Errorf("Tip geomean %v, want 0", g)
+	}
+	if g, ok := p.Geomean["Other"]; !ok || g != 0 {
+		t.Errorf("Other geomean %v, %v, want 0", g, ok)
+	}
+	if p := makePanel("ns/op", s, "Other", []string{"Other"}); p != nil {
+		t.Errorf("panel with only the base configuration has deltas %v", p.Deltas)
+	}
+}
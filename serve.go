@@ -0,0 +1,392 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// serveDir holds the output of each run made by "bent serve".
+var serveDir = benchDir + "/serve"
+
+// serveJob is one run requested from "bent serve".  The first five fields
+// are the request; the rest are filled in by the server.
+type serveJob struct {
+	Benchmarks     string // As for -b, default all
+	Configurations string // As for -c, default all
+	N              int    // As for -N, if positive
+	Builds         int    // As for -a, if positive; this is build benchmarking
+	Test           bool   // As for -T
+
+	ID       int
+	State    string // "queued", "running", "done", or "failed"
+	Args     []string
+	Runstamp string
+	ExitCode int
+	Queued   time.Time
+	Started  time.Time // Zero until the run starts
+	Finished time.Time // Zero until the run finishes
+	Output   string    // Name of the file holding bent's output for this run
+}
+
+// manifest lists the results of one run, see writeManifest.
+type manifest struct {
+	Runstamp string
+	Job      *serveJob
+	Files    []string
+}
+
+// server runs the jobs queued with its API one at a time, in order.
+type server struct {
+	mu   sync.Mutex
+	jobs []*serveJob // all jobs, by ID-1
+	wake chan bool   // signals run that a job was queued
+	bent string      // this binary
+	args []string    // bent flags for every run
+}
+
+// job returns the job with the given ID, or nil.
+func (s *server) job(id int) *serveJob {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if id < 1 || id > len(s.jobs) {
+		return nil
+	}
+	j := *s.jobs[id-1]
+	return &j
+}
+
+// add queues the run requested by j and returns the job.
+func (s *server) add(j *serveJob) *serveJob {
+	s.mu.Lock()
+	j.ID = len(s.jobs) + 1
+	j.State = "queued"
+	j.Queued = time.Now()
+	j.Output = fmt.Sprintf("%s/%d.log", serveDir, j.ID)
+	j.Args = append([]string{"-L", j.stampFile()}, s.args...)
+	if j.Benchmarks != "" {
+		j.Args = append(j.Args, "-b", j.Benchmarks)
+	}
+	if j.Configurations != "" {
+		j.Args = append(j.Args, "-c", j.Configurations)
+	}
+	if j.N > 0 {
+		j.Args = append(j.Args, "-N", strconv.Itoa(j.N))
+	}
+	if j.Builds > 0 {
+		j.Args = append(j.Args, "-a="+strconv.Itoa(j.Builds))
+	}
+	if j.Test {
+		j.Args = append(j.Args, "-T")
+	}
+	s.jobs = append(s.jobs, j)
+	c := *j
+	s.mu.Unlock()
+	select {
+	case s.wake <- true:
+	default: // already awake
+	}
+	return &c
+}
+
+// stampFile returns the name of the file to which the run for j logs its runstamp.
+func (j *serveJob) stampFile() string {
+	return fmt.Sprintf("%s/%d.stamp", serveDir, j.ID)
+}
+
+// next returns the first queued job, or nil if there is none.
+func (s *server) next() *serveJob {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, j := range s.jobs {
+		if j.State == "queued" {
+			return j
+		}
+	}
+	return nil
+}
+
+// update applies f to job j while holding the lock.
+func (s *server) update(j *serveJob, f func(j *serveJob)) {
+	s.mu.Lock()
+	f(j)
+	s.mu.Unlock()
+}
+
+// run runs the queued jobs, one at a time, forever.
+func (s *server) run() {
+	for {
+		j := s.next()
+		if j == nil {
+			<-s.wake
+			continue
+		}
+		s.update(j, func(j *serveJob) {
+			j.State = "running"
+			j.Started = time.Now()
+		})
+		rc := s.runJob(j)
+		s.update(j, func(j *serveJob) {
+			j.Finished = time.Now()
+			j.ExitCode = rc
+			j.State = "done"
+			if rc != 0 {
+				j.State = "failed"
+			}
+		})
+		if j.Runstamp != "" {
+			if err := writeManifest(s.job(j.ID)); err != nil {
+				fmt.Printf("There was an error writing the manifest for %s, %v\n", j.Runstamp, err)
+			}
+		}
+		fmt.Printf("Job %d (%s) %s, rc = %d\n", j.ID, j.Runstamp, j.State, rc)
+	}
+}
+
+// runJob runs bent for j, and returns its exit code.
+func (s *server) runJob(j *serveJob) int {
+	out, err := os.Create(j.Output)
+	if err != nil {
+		fmt.Printf("There was an error creating %s, %v\n", j.Output, err)
+		return 2
+	}
+	defer out.Close()
+	cmd := exec.Command(s.bent, j.Args...)
+	cmd.Stdout = out
+	cmd.Stderr = out
+	fmt.Printf("Job %d: %s\n", j.ID, asCommandLine("", cmd))
+	if err := cmd.Start(); err != nil {
+		fmt.Fprintf(out, "There was an error starting %s, %v\n", s.bent, err)
+		return 2
+	}
+	// bent logs its runstamp (to the -L file) before it starts work.
+	done := make(chan error, 1)
+	go func() { done <- cmd.Wait() }()
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			s.readStamp(j)
+		case err := <-done:
+			s.readStamp(j)
+			if ee, ok := err.(*exec.ExitError); ok {
+				return ee.ExitCode()
+			} else if err != nil {
+				return 2
+			}
+			return 0
+		}
+	}
+}
+
+// readStamp sets j's Runstamp from its stampFile, once the run has logged it.
+func (s *server) readStamp(j *serveJob) {
+	if j.Runstamp != "" {
+		return
+	}
+	data, err := ioutil.ReadFile(j.stampFile())
+	if err != nil {
+		return
+	}
+	if i := strings.IndexAny(string(data), "\t\n"); i > 0 {
+		s.update(j, func(j *serveJob) { j.Runstamp = string(data[:i]) })
+	}
+}
+
+// resultFiles returns the files in the bench directory from the run with runstamp.
+func resultFiles(runstamp string) []string {
+	files, _ := filepath.Glob(benchDir + "/" + runstamp + ".*")
+	filepath.Walk(benchDir+"/"+runstamp, func(path string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			files = append(files, path)
+		}
+		return nil
+	})
+	sort.Strings(files)
+	return files
+}
+
+// writeManifest writes bench/<runstamp>.manifest.json, listing j's result files.
+func writeManifest(j *serveJob) error {
+	name := benchDir + "/" + j.Runstamp + ".manifest.json"
+	m := manifest{Runstamp: j.Runstamp, Job: j, Files: append(resultFiles(j.Runstamp), j.Output)}
+	data, err := json.MarshalIndent(&m, "", "\t")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(name, append(data, '\n'), 0664)
+}
+
+// reply writes v to w as JSON, with status code.
+func reply(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "\t")
+	enc.Encode(v)
+}
+
+// replyError writes an error message to w as JSON, with status code.
+func replyError(w http.ResponseWriter, code int, format string, args ...interface{}) {
+	reply(w, code, map[string]string{"Error": fmt.Sprintf(format, args...)})
+}
+
+// handleRuns serves /runs: GET lists all jobs, POST queues a new one.
+func (s *server) handleRuns(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case "GET":
+		s.mu.Lock()
+		jobs := make([]serveJob, len(s.jobs))
+		for i, j := range s.jobs {
+			jobs[i] = *j
+		}
+		s.mu.Unlock()
+		reply(w, http.StatusOK, jobs)
+	case "POST":
+		// Only JSON, which a web page cannot send to another site without its consent (CORS),
+		// so that pages open in a browser on this machine cannot queue runs.
+		if mt, _, err := mime.ParseMediaType(r.Header.Get("Content-Type")); err != nil || mt != "application/json" {
+			replyError(w, http.StatusUnsupportedMediaType, "run requests must have Content-Type application/json")
+			return
+		}
+		j := &serveJob{}
+		dec := json.NewDecoder(r.Body)
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(j); err != nil {
+			replyError(w, http.StatusBadRequest, "bad run request, %v", err)
+			return
+		}
+		if j.N < 0 || j.Builds < 0 {
+			replyError(w, http.StatusBadRequest, "N and Builds may not be negative")
+			return
+		}
+		reply(w, http.StatusCreated, s.add(&serveJob{
+			Benchmarks:     j.Benchmarks,
+			Configurations: j.Configurations,
+			N:              j.N,
+			Builds:         j.Builds,
+			Test:           j.Test,
+		}))
+	default:
+		replyError(w, http.StatusMethodNotAllowed, "%s not allowed, use GET or POST", r.Method)
+	}
+}
+
+// handleRun serves /runs/<id>, the job's status, and /runs/<id>/output, its output so far.
+func (s *server) handleRun(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/runs/")
+	output := strings.HasSuffix(path, "/output")
+	id, err := strconv.Atoi(strings.TrimSuffix(path, "/output"))
+	j := s.job(id)
+	if err != nil || j == nil {
+		replyError(w, http.StatusNotFound, "no run %s", path)
+		return
+	}
+	if !output {
+		reply(w, http.StatusOK, j)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	http.ServeFile(w, r, j.Output)
+}
+
+// handleResults serves /results/<runstamp>, the manifest for that run.
+// Runs not made by this server are listed from the bench directory.
+func (s *server) handleResults(w http.ResponseWriter, r *http.Request) {
+	stamp := strings.TrimPrefix(r.URL.Path, "/results/")
+	if stamp == "" || strings.ContainsAny(stamp, "/.") {
+		replyError(w, http.StatusNotFound, "no results for %q", stamp)
+		return
+	}
+	if data, err := ioutil.ReadFile(benchDir + "/" + stamp + ".manifest.json"); err == nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(data)
+		return
+	}
+	m := manifest{Runstamp: stamp, Files: resultFiles(stamp)}
+	s.mu.Lock()
+	for _, j := range s.jobs {
+		if j.Runstamp == stamp {
+			c := *j
+			m.Job = &c
+		}
+	}
+	s.mu.Unlock()
+	if len(m.Files) == 0 && m.Job == nil {
+		replyError(w, http.StatusNotFound, "no results for %q", stamp)
+		return
+	}
+	reply(w, http.StatusOK, &m)
+}
+
+// serveMain implements "bent serve [-addr host:port] [-- bent flags]", which
+// accepts run requests over HTTP and runs them one at a time in the current
+// directory, so that scheduled and ad-hoc runs do not disturb each other.
+// The bent flags, e.g. -U or -C, are used for every run.  It returns the exit code.
+func serveMain(args []string) int {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := fs.String("addr", "localhost:8040", "address to listen on")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage of %s serve [flags] [-- bent flags for every run]:\n", os.Args[0])
+		fs.PrintDefaults()
+		fmt.Fprintf(os.Stderr, `
+API:
+  POST /runs                queue a run, e.g. {"Benchmarks": "gonum_*", "Configurations": "Base,Tip", "N": 5}
+                            (also "Builds", as for -a, and "Test", as for -T), as application/json
+  GET  /runs                status of all runs
+  GET  /runs/<id>           status of one run
+  GET  /runs/<id>/output    bent's output for the run
+  GET  /results/<runstamp>  the files produced by the run
+`)
+	}
+	fs.Parse(args)
+	for _, a := range fs.Args() {
+		if a == "-L" || a == "--L" || strings.HasPrefix(a, "-L=") || strings.HasPrefix(a, "--L=") {
+			fmt.Printf("bent serve sets -L for each run, to learn its runstamp, so it may not be among the bent flags\n")
+			return 1
+		}
+	}
+
+	bent, err := os.Executable()
+	if err != nil {
+		fmt.Printf("Could not find the bent executable, %v\n", err)
+		return 1
+	}
+	if _, err := os.Stat("Dockerfile"); err != nil {
+		fmt.Printf("Missing 'Dockerfile', please run bent -I in this directory first.\n")
+		return 1
+	}
+	if err := os.MkdirAll(serveDir, 0775); err != nil {
+		fmt.Printf("There was an error creating %s, %v\n", serveDir, err)
+		return 1
+	}
+	s := &server{wake: make(chan bool, 1), bent: bent, args: fs.Args()}
+	go s.run()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/runs", s.handleRuns)
+	mux.HandleFunc("/runs/", s.handleRun)
+	mux.HandleFunc("/results/", s.handleResults)
+	fmt.Printf("Serving bent runs on http://%s/runs\n", *addr)
+	if err := http.ListenAndServe(*addr, mux); err != nil {
+		fmt.Printf("%v\n", err)
+		return 1
+	}
+	return 0
+}
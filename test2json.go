@@ -0,0 +1,219 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A testEvent is one event in the output of a test binary, in the same
+// form as cmd/test2json, plus fields that identify the bent benchmark,
+// configuration and run (iteration) that produced it.
+//
+// Action is one of "run", "pause", "cont", "pass", "fail", "skip",
+// "bench" (a benchmark result line) or "output".  Events with an empty
+// Test apply to the whole test binary.
+type testEvent struct {
+	Time      time.Time `json:",omitempty"`
+	Action    string
+	Package   string   `json:",omitempty"`
+	Test      string   `json:",omitempty"`
+	Elapsed   *float64 `json:",omitempty"` // seconds
+	Output    string   `json:",omitempty"`
+	Benchmark string   `json:",omitempty"` // bent benchmark name
+	Config    string   `json:",omitempty"` // bent configuration name
+	Run       int      // bent iteration, BENT_I
+	Retry     int      `json:",omitempty"` // for reruns of a failed test, which rerun this is
+}
+
+var (
+	// "=== RUN   TestFoo", also PAUSE, CONT
+	updateRE = regexp.MustCompile(`^=== (RUN|PAUSE|CONT|NAME)\s+(\S+)`)
+	// "--- PASS: TestFoo (0.01s)", possibly indented for subtests; also FAIL, SKIP
+	reportRE = regexp.MustCompile(`^\s*--- (PASS|FAIL|SKIP): (\S+) \(([0-9.]+)s\)`)
+	// "BenchmarkFoo-8   	 1000	  1234 ns/op"
+	benchRE = regexp.MustCompile(`^(Benchmark\S*)\s+\d+\s+`)
+	// "ok  	pkg	0.01s" or "FAIL	pkg	0.01s"
+	pkgRE = regexp.MustCompile(`^(ok  |FAIL)\t\S+\t([0-9.]+)s`)
+)
+
+// A testConverter turns the lines printed by a test binary into testEvents,
+// much as cmd/test2json does.  It is not safe for concurrent use; runBinary
+// serializes the lines from stdout and stderr.
+type testConverter struct {
+	proto  testEvent       // supplies Package, Benchmark, Config, Run
+	emit   func(testEvent) // receives each event
+	start  time.Time
+	test   string // most recently started or resumed test, for attributing output
+	result string // "pass" or "fail" if the binary printed PASS or FAIL
+	done   bool
+	failed []string // top-level tests that failed
+	quiet  *quietFilter
+}
+
+func newTestConverter(proto testEvent, emit func(testEvent)) *testConverter {
+	return &testConverter{proto: proto, emit: emit, start: time.Now()}
+}
+
+func (c *testConverter) event(action, test string, elapsed *float64, output string) {
+	e := c.proto
+	e.Time = time.Now()
+	e.Action = action
+	e.Test = test
+	e.Elapsed = elapsed
+	e.Output = output
+	c.emit(e)
+}
+
+// handleLine processes one line (including its trailing newline, if any) of output.
+func (c *testConverter) handleLine(line string) {
+	trimmed := strings.TrimRight(line, "\r\n")
+
+	if m := updateRE.FindStringSubmatch(trimmed); m != nil {
+		action := strings.ToLower(m[1])
+		c.test = m[2]
+		if action != "name" {
+			c.event(action, m[2], nil, "")
+		}
+		c.event("output", c.test, nil, line)
+		return
+	}
+	if m := reportRE.FindStringSubmatch(trimmed); m != nil {
+		elapsed, _ := strconv.ParseFloat(m[3], 64)
+		c.event("output", m[2], nil, line)
+		c.event(strings.ToLower(m[1]), m[2], &elapsed, "")
+		if m[1] == "FAIL" && !strings.Contains(m[2], "/") {
+			c.failed = append(c.failed, m[2])
+		}
+		c.test = m[2] // e.g., a skip message may follow the report
+		return
+	}
+	if m := benchRE.FindStringSubmatch(trimmed); m != nil {
+		c.event("bench", m[1], nil, line)
+		return
+	}
+	switch {
+	case trimmed == "PASS":
+		c.result = "pass"
+		c.test = ""
+	case trimmed == "FAIL":
+		c.result = "fail"
+		c.test = ""
+	case pkgRE.MatchString(trimmed):
+		c.test = ""
+	}
+	c.event("output", c.test, nil, line)
+}
+
+// finish emits the final event for the whole test binary, which exited with rc.
+func (c *testConverter) finish(rc int) {
+	if c.done {
+		return
+	}
+	c.done = true
+	action := c.result
+	switch {
+	case rc != 0:
+		action = "fail"
+	case action == "":
+		action = "pass"
+	}
+	elapsed := time.Since(c.start).Seconds()
+	c.event(action, "", &elapsed, "")
+}
+
+// plain returns what line would have been, if anything, had the test binary
+// not been run with -test.v, if bent added that flag (see quietFilter).
+func (c *testConverter) plain(line string) string {
+	if c.quiet == nil {
+		return line
+	}
+	return c.quiet.filter(line)
+}
+
+// A quietFilter recovers, from the output of a test binary run with
+// -test.v, approximately what it would have printed without -test.v.
+// When running tests, bent adds -test.v so that testConverter sees each
+// test's result, but the .stdout files keep their usual content:
+// the === lines are dropped, as are passing and skipped tests' reports
+// and output, and the output of a failing test follows its report.
+type quietFilter struct {
+	current string              // test to which indented output belongs, "" if none
+	pending map[string][]string // indented output of tests not yet reported
+	drop    int                 // if positive, one more than the indentation of a PASS or SKIP report, see filter
+}
+
+func newQuietFilter() *quietFilter {
+	return &quietFilter{pending: make(map[string][]string)}
+}
+
+// indent returns the number of leading spaces in line.
+func indent(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+// filter returns what remains of line, including its newline, if anything.
+// The lines following a PASS or SKIP report that are indented more than it
+// (its subtests' reports, or its output, in older versions of Go) are dropped.
+func (q *quietFilter) filter(line string) string {
+	trimmed := strings.TrimRight(line, "\r\n")
+	if q.drop > 0 {
+		if trimmed != "" && indent(trimmed) >= q.drop {
+			return ""
+		}
+		q.drop = 0
+	}
+	if m := updateRE.FindStringSubmatch(trimmed); m != nil {
+		q.current = m[2]
+		return ""
+	}
+	if m := reportRE.FindStringSubmatch(trimmed); m != nil {
+		out := q.pending[m[2]]
+		delete(q.pending, m[2])
+		q.current = ""
+		if m[1] != "FAIL" {
+			q.drop = indent(trimmed) + 1
+			return ""
+		}
+		// Streamed output is indented four spaces; without -test.v,
+		// output is indented four spaces more than its report.
+		pad := strings.Repeat(" ", indent(trimmed))
+		for _, o := range out {
+			if !strings.HasSuffix(line, "\n") {
+				line += "\n"
+			}
+			line += pad + o
+		}
+		return line
+	}
+	if q.current != "" && strings.HasPrefix(trimmed, "    ") {
+		q.pending[q.current] = append(q.pending[q.current], line)
+		return ""
+	}
+	return line
+}
+
+// jsonEventWriter returns an event sink that writes each event
+// as a line of JSON to w.
+func jsonEventWriter(w io.Writer) func(testEvent) {
+	enc := json.NewEncoder(w)
+	return func(e testEvent) {
+		enc.Encode(e)
+	}
+}
+
+// teeEvents returns an event sink that passes each event to all of sinks.
+func teeEvents(sinks ...func(testEvent)) func(testEvent) {
+	return func(e testEvent) {
+		for _, sink := range sinks {
+			sink(e)
+		}
+	}
+}
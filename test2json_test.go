@@ -0,0 +1,136 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// verboseOutput is the output of a test binary run with
+// -test.v -test.bench=. (from Go 1.22), for a package with a passing test
+// that logs, a failing test with one passing and one failing subtest,
+// a skipped test, and a benchmark.
+const verboseOutput = `=== RUN   TestA
+    a_test.go:5: log a
+--- PASS: TestA (0.00s)
+=== RUN   TestB
+=== RUN   TestB/x
+    b_test.go:9: log x
+=== RUN   TestB/y
+    b_test.go:10: log y
+--- FAIL: TestB (0.01s)
+    --- PASS: TestB/x (0.00s)
+    --- FAIL: TestB/y (0.00s)
+=== RUN   TestC
+    c_test.go:12: not on this machine
+--- SKIP: TestC (0.00s)
+goos: linux
+goarch: amd64
+pkg: example.com/p
+BenchmarkX
+BenchmarkX-8   	 1000000	      1043 ns/op
+FAIL
+`
+
+// lines splits s into lines, each with its newline, as runBinary reads them.
+func lines(s string) []string {
+	ls := strings.SplitAfter(s, "\n")
+	if ls[len(ls)-1] == "" {
+		ls = ls[:len(ls)-1]
+	}
+	return ls
+}
+
+func TestTestConverter(t *testing.T) {
+	type ev struct{ action, test string }
+	var got []ev
+	c := newTestConverter(testEvent{Benchmark: "p"}, func(e testEvent) {
+		if e.Action != "output" {
+			got = append(got, ev{e.Action, e.Test})
+		}
+	})
+	for _, l := range lines(verboseOutput) {
+		c.handleLine(l)
+	}
+	c.finish(1)
+	want := []ev{
+		{"run", "TestA"},
+		{"pass", "TestA"},
+		{"run", "TestB"},
+		{"run", "TestB/x"},
+		{"run", "TestB/y"},
+		{"fail", "TestB"},
+		{"pass", "TestB/x"},
+		{"fail", "TestB/y"},
+		{"run", "TestC"},
+		{"skip", "TestC"},
+		{"bench", "BenchmarkX-8"},
+		{"fail", ""},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("events:\ngot  %v\nwant %v", got, want)
+	}
+	if want := []string{"TestB"}; !reflect.DeepEqual(c.failed, want) {
+		t.Errorf("failed = %v, want %v", c.failed, want)
+	}
+}
+
+func TestTestConverterResult(t *testing.T) {
+	tests := []struct {
+		output string
+		rc     int
+		want   string
+	}{
+		{"PASS\n", 0, "pass"},
+		{"--- FAIL: TestA (0.00s)\nFAIL\n", 1, "fail"},
+		{"PASS\n", 2, "fail"}, // e.g., killed after printing PASS
+		{"", 0, "pass"},
+		{"ok  \texample.com/p\t0.01s\n", 0, "pass"},
+	}
+	for _, tt := range tests {
+		var last testEvent
+		c := newTestConverter(testEvent{}, func(e testEvent) { last = e })
+		for _, l := range lines(tt.output) {
+			c.handleLine(l)
+		}
+		c.finish(tt.rc)
+		if last.Action != tt.want || last.Test != "" {
+			t.Errorf("%q, rc %d: final event %s %q, want %s", tt.output, tt.rc, last.Action, last.Test, tt.want)
+		}
+	}
+}
+
+func TestQuietFilter(t *testing.T) {
+	tests := []struct {
+		name, verbose, want string
+	}{
+		{"pass", verboseOutput[:strings.Index(verboseOutput, "=== RUN   TestB\n")], ""},
+		{"fail", verboseOutput[strings.Index(verboseOutput, "=== RUN   TestB\n"):strings.Index(verboseOutput, "=== RUN   TestC\n")], `--- FAIL: TestB (0.01s)
+    --- FAIL: TestB/y (0.00s)
+        b_test.go:10: log y
+`},
+		{"skip", verboseOutput[strings.Index(verboseOutput, "=== RUN   TestC\n"):strings.Index(verboseOutput, "goos:")], ""},
+		{"bench", verboseOutput[strings.Index(verboseOutput, "goos:"):], `goos: linux
+goarch: amd64
+pkg: example.com/p
+BenchmarkX
+BenchmarkX-8   	 1000000	      1043 ns/op
+FAIL
+`},
+		{"unindented output", "=== RUN   TestD\nprinted\n--- PASS: TestD (0.00s)\nPASS\n", "printed\nPASS\n"},
+	}
+	for _, tt := range tests {
+		q := newQuietFilter()
+		var got strings.Builder
+		for _, l := range lines(tt.verbose) {
+			got.WriteString(q.filter(l))
+		}
+		if got.String() != tt.want {
+			t.Errorf("%s: got\n%s\nwant\n%s", tt.name, got.String(), tt.want)
+		}
+	}
+}
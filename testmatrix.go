@@ -0,0 +1,232 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+)
+
+// testOutcomes counts the results of one test in one configuration, across runs.
+type testOutcomes struct {
+	Pass, Fail, Skip int
+}
+
+// status summarizes o for a table cell.
+func (o *testOutcomes) status() string {
+	switch {
+	case o == nil:
+		return "-"
+	case o.Fail == 0 && o.Pass > 0:
+		return "ok"
+	case o.Fail == 0:
+		return "skip"
+	case o.Pass == 0:
+		return "FAIL"
+	}
+	return fmt.Sprintf("FLAKY %d/%d", o.Fail, o.Fail+o.Pass)
+}
+
+type testKey struct {
+	Benchmark, Test string // Test is empty for the test binary as a whole
+}
+
+// A testMatrix records the outcome of every test in every benchmark,
+// for each configuration, from the test events of all runs.
+type testMatrix struct {
+	configs []string // configurations, in order of first appearance
+	results map[testKey]map[string]*testOutcomes
+}
+
+func newTestMatrix() *testMatrix {
+	return &testMatrix{results: make(map[testKey]map[string]*testOutcomes)}
+}
+
+// record is a test event sink that notes pass, fail and skip events.
+// A rerun of a failed test counts for that test, but not for the test
+// binary as a whole.
+func (m *testMatrix) record(e testEvent) {
+	switch e.Action {
+	case "pass", "fail", "skip":
+	default:
+		return
+	}
+	if e.Retry > 0 && e.Test == "" {
+		return
+	}
+	k := testKey{Benchmark: e.Benchmark, Test: e.Test}
+	byConfig := m.results[k]
+	if byConfig == nil {
+		byConfig = make(map[string]*testOutcomes)
+		m.results[k] = byConfig
+	}
+	o := byConfig[e.Config]
+	if o == nil {
+		o = &testOutcomes{}
+		byConfig[e.Config] = o
+		found := false
+		for _, c := range m.configs {
+			found = found || c == e.Config
+		}
+		if !found {
+			m.configs = append(m.configs, e.Config)
+		}
+	}
+	switch e.Action {
+	case "pass":
+		o.Pass++
+	case "fail":
+		o.Fail++
+	case "skip":
+		o.Skip++
+	}
+}
+
+// keys returns the tests in m, sorted by benchmark and test.
+func (m *testMatrix) keys() []testKey {
+	var ks []testKey
+	for k := range m.results {
+		ks = append(ks, k)
+	}
+	sort.Slice(ks, func(i, j int) bool {
+		if ks[i].Benchmark != ks[j].Benchmark {
+			return ks[i].Benchmark < ks[j].Benchmark
+		}
+		return ks[i].Test < ks[j].Test
+	})
+	return ks
+}
+
+// differs reports whether test k consistently passes in some configuration
+// and consistently fails in another, i.e., a regression (or a fix).
+func (m *testMatrix) differs(k testKey) bool {
+	passes, fails := false, false
+	for _, o := range m.results[k] {
+		passes = passes || o.Pass > 0 && o.Fail == 0
+		fails = fails || o.Fail > 0 && o.Pass == 0
+	}
+	return passes && fails
+}
+
+// flaky reports whether test k both passed and failed in the same configuration.
+func (m *testMatrix) flaky(k testKey) bool {
+	for _, o := range m.results[k] {
+		if o.Pass > 0 && o.Fail > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func testName(k testKey) string {
+	if k.Test == "" {
+		return "(all)"
+	}
+	return k.Test
+}
+
+// markdown renders m as a markdown table; rows for tests whose
+// results differ between configurations are marked with "≠" and
+// emboldened, and rows for flaky tests are marked with "~".
+func (m *testMatrix) markdown() []byte {
+	buf := new(bytes.Buffer)
+	fmt.Fprintf(buf, "| | Benchmark | Test | %s |\n", strings.Join(m.configs, " | "))
+	fmt.Fprintf(buf, "| --- | --- | --- |%s\n", strings.Repeat(" --- |", len(m.configs)))
+	for _, k := range m.keys() {
+		mark, em := "", ""
+		if m.differs(k) {
+			mark, em = "≠", "**"
+		}
+		if m.flaky(k) {
+			mark += "~"
+		}
+		fmt.Fprintf(buf, "| %s | %s | `%s` |", mark, k.Benchmark, testName(k))
+		for _, c := range m.configs {
+			fmt.Fprintf(buf, " %s%s%s |", em, m.results[k][c].status(), em)
+		}
+		fmt.Fprintln(buf)
+	}
+	return buf.Bytes()
+}
+
+type testMatrixRow struct {
+	Benchmark string
+	Test      string // empty for the test binary as a whole
+	Results   map[string]*testOutcomes
+	Differs   bool // consistently passes in some configuration and fails in another
+	Flaky     bool // both passes and fails in some configuration
+}
+
+// json renders m as JSON.
+func (m *testMatrix) json() ([]byte, error) {
+	var out struct {
+		Configurations []string
+		Tests          []testMatrixRow
+	}
+	out.Configurations = m.configs
+	for _, k := range m.keys() {
+		out.Tests = append(out.Tests, testMatrixRow{Benchmark: k.Benchmark, Test: k.Test, Results: m.results[k], Differs: m.differs(k), Flaky: m.flaky(k)})
+	}
+	return json.MarshalIndent(&out, "", "\t")
+}
+
+// report writes the markdown and JSON forms of m to the bench directory
+// and prints the tests whose results differ between configurations.
+func (m *testMatrix) report() {
+	if len(m.results) == 0 {
+		return
+	}
+	md := runBenchName("tests.md")
+	if err := ioutil.WriteFile(md, m.markdown(), 0664); err != nil {
+		fmt.Printf("There was an error writing %s, %v\n", md, err)
+	}
+	js := runBenchName("tests.json")
+	if b, err := m.json(); err != nil {
+		fmt.Printf("There was an error encoding test results, %v\n", err)
+	} else if err := ioutil.WriteFile(js, b, 0664); err != nil {
+		fmt.Printf("There was an error writing %s, %v\n", js, err)
+	}
+
+	var differ, flaky []testKey
+	failed := 0
+	for _, k := range m.keys() {
+		if m.differs(k) {
+			differ = append(differ, k)
+		}
+		if m.flaky(k) {
+			flaky = append(flaky, k)
+		}
+		for _, o := range m.results[k] {
+			if o.Fail > 0 {
+				failed++
+				break
+			}
+		}
+	}
+	fmt.Printf("Test results: %d tests, %d with failures, %d differ between configurations, %d flaky; see %s and %s\n",
+		len(m.results), failed, len(differ), len(flaky), md, js)
+	if len(differ) > 0 {
+		fmt.Println("Consistent differences between configurations:")
+		m.printRows(differ)
+	}
+	if len(flaky) > 0 {
+		fmt.Println("Flaky (both passed and failed in the same configuration):")
+		m.printRows(flaky)
+	}
+}
+
+func (m *testMatrix) printRows(ks []testKey) {
+	for _, k := range ks {
+		s := "   " + k.Benchmark + " " + testName(k) + ":"
+		for _, c := range m.configs {
+			s += " " + c + "=" + m.results[k][c].status()
+		}
+		fmt.Println(s)
+	}
+}
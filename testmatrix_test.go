@@ -0,0 +1,122 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// matrixEvents builds test events from lines of the form
+// "config benchmark test action [retry]"; test "-" is the binary as a whole.
+func matrixEvents(s string) []testEvent {
+	var es []testEvent
+	for _, l := range strings.Split(strings.TrimSpace(s), "\n") {
+		f := strings.Fields(l)
+		e := testEvent{Config: f[0], Benchmark: f[1], Test: f[2], Action: f[3]}
+		if e.Test == "-" {
+			e.Test = ""
+		}
+		if len(f) > 4 {
+			e.Retry = 1
+		}
+		es = append(es, e)
+	}
+	return es
+}
+
+func TestTestMatrix(t *testing.T) {
+	tests := []struct {
+		name   string
+		events string
+		want   string // "benchmark test: status in each configuration [differs] [flaky]", one per line
+	}{
+		{"same", `
+Base p TestA pass
+Tip p TestA pass
+Base p - pass
+Tip p - pass
+`, `
+p (all): ok ok
+p TestA: ok ok
+`},
+		{"regression", `
+Base p TestA pass
+Tip p TestA fail
+Tip p TestB skip
+Base p TestB skip
+Base p - pass
+Tip p - fail
+`, `
+p (all): ok FAIL differs
+p TestA: ok FAIL differs
+p TestB: skip skip
+`},
+		{"flaky", `
+Base p TestA pass
+Base p TestA fail
+Tip p TestA fail
+Tip p - fail
+Tip p TestA pass retry
+Tip p - pass retry
+`, `
+p (all): - FAIL
+p TestA: FLAKY 1/2 FLAKY 1/2 flaky
+`},
+		// Configurations are ordered by their first result, so Tip is first.
+		{"missing", `
+Base p TestA run
+Base p TestA output
+Tip q TestA pass
+Base p TestA pass
+`, `
+p TestA: - ok
+q TestA: ok -
+`},
+	}
+	for _, tt := range tests {
+		m := newTestMatrix()
+		for _, e := range matrixEvents(tt.events) {
+			m.record(e)
+		}
+		var got []string
+		for _, k := range m.keys() {
+			s := k.Benchmark + " " + testName(k) + ":"
+			for _, c := range m.configs {
+				s += " " + m.results[k][c].status()
+			}
+			if m.differs(k) {
+				s += " differs"
+			}
+			if m.flaky(k) {
+				s += " flaky"
+			}
+			got = append(got, s)
+		}
+		want := strings.Split(strings.TrimSpace(tt.want), "\n")
+		if strings.Join(got, "\n") != strings.Join(want, "\n") {
+			t.Errorf("%s:\ngot\n%s\nwant\n%s", tt.name, strings.Join(got, "\n"), strings.Join(want, "\n"))
+		}
+	}
+}
+
+func TestTestMatrixMarkdown(t *testing.T) {
+	m := newTestMatrix()
+	for _, e := range matrixEvents(`
+Base p TestA pass
+Tip p TestA fail
+Base p TestB pass
+Tip p TestB pass
+`) {
+		m.record(e)
+	}
+	want := "| | Benchmark | Test | Base | Tip |\n" +
+		"| --- | --- | --- | --- | --- |\n" +
+		"| ≠ | p | `TestA` | **ok** | **FAIL** |\n" +
+		"|  | p | `TestB` | ok | ok |\n"
+	if got := string(m.markdown()); got != want {
+		t.Errorf("markdown:\n%s\nwant\n%s", got, want)
+	}
+}
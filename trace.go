@@ -0,0 +1,352 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// This is a reader for just enough of the execution trace format written by
+// Go 1.22 and later (see internal/trace in the Go source) to summarize a trace.
+// Events from all Ms are ordered by their timestamps, rather than by also
+// reconstructing the partial order the runtime records with sequence numbers,
+// which is close enough for totals and latencies.  Traces from earlier versions
+// of Go use a different format, and are reported as unsupported.
+
+// Event types, from internal/trace/tracev2.
+const (
+	evEventBatch          = 1
+	evStacks              = 2
+	evStrings             = 4
+	evCPUSamples          = 6
+	evFrequency           = 8
+	evGoCreate            = 14
+	evGoCreateSyscall     = 15
+	evGoStart             = 16
+	evGoDestroy           = 17
+	evGoDestroySyscall    = 18
+	evGoStop              = 19
+	evGoBlock             = 20
+	evGoUnblock           = 21
+	evGoSyscallEndBlocked = 24
+	evGoStatus            = 25
+	evSTWBegin            = 26
+	evSTWEnd              = 27
+	evGCActive            = 28
+	evGCBegin             = 29
+	evGCEnd               = 30
+	evGoSwitch            = 45
+	evGoSwitchDestroy     = 46
+	evGoCreateBlocked     = 47
+	evGoStatusStack       = 48
+	evExperimentalBatch   = 49
+	evSync                = 50
+	evClockSnapshot       = 51
+	evEndOfGeneration     = 52
+)
+
+// traceArgs is the number of arguments, including the timestamp delta,
+// of each type of event that may appear in an event batch.
+var traceArgs = [...]int{
+	9: 3, 3, 1, 4, 3, // ProcsChange, ProcStart, ProcStop, ProcSteal, ProcStatus
+	4, 2, 3, 1, 1, 3, 3, 4, 3, 1, 1, 4, // GoCreate ... GoStatus
+	3, 1, // STWBegin, STWEnd
+	2, 3, 2, 2, 2, 3, 2, 2, 1, 2, 2, // GCActive ... HeapGoal
+	2, 5, 3, 4, 4, 5, // GoLabel, UserTaskBegin ... UserLog
+	3, 3, 4, 5, // GoSwitch, GoSwitchDestroy, GoCreateBlocked, GoStatusStack
+	51: 4, // ClockSnapshot
+}
+
+// Goroutine statuses in GoStatus events.
+const (
+	goRunnable = 1
+	goRunning  = 2
+	goSyscall  = 3
+)
+
+// traceEvent is one timed event from a trace.
+type traceEvent struct {
+	time int64  // nanoseconds
+	m    uint64 // the M (thread) whose batch held this event
+	typ  byte
+	args [4]uint64 // arguments after the timestamp delta
+}
+
+// traceSummary summarizes one execution trace.
+type traceSummary struct {
+	duration   int64   // ns from the first to the last event
+	gcTime     int64   // ns during which a GC was in progress
+	stwTime    int64   // ns stopped-the-world
+	latencies  []int64 // ns from runnable to running, each time a goroutine was scheduled
+	goroutines int     // goroutines created
+	maxLive    int     // most goroutines in existence at once
+}
+
+// uvarints decodes a sequence of uvarints from data, starting at off.
+type uvarints struct {
+	data []byte
+	off  int
+	err  error
+}
+
+func (u *uvarints) next() uint64 {
+	if u.err != nil {
+		return 0
+	}
+	v, n := binary.Uvarint(u.data[u.off:])
+	if n <= 0 {
+		u.err = errors.New("bad varint, trace is truncated or corrupt")
+		return 0
+	}
+	u.off += n
+	return v
+}
+
+// traceVersion returns the minor Go version of the trace format in data,
+// and the length of its header.
+func traceVersion(data []byte) (int, int, error) {
+	const prefix, suffix = "go 1.", " trace\x00\x00\x00"
+	s := string(data)
+	if len(s) > 16 {
+		s = s[:16]
+	}
+	i := strings.Index(s, suffix)
+	if !strings.HasPrefix(s, prefix) || i < 0 {
+		return 0, 0, errors.New("not a Go execution trace")
+	}
+	v, err := strconv.Atoi(s[len(prefix):i])
+	if err != nil {
+		return 0, 0, errors.New("not a Go execution trace")
+	}
+	if v < 22 {
+		return v, 0, fmt.Errorf("trace format go 1.%d is not supported, use go tool trace", v)
+	}
+	return v, i + len(suffix), nil
+}
+
+// readTraceEvents returns the timed events in the trace data, ordered by time.
+func readTraceEvents(data []byte) ([]traceEvent, error) {
+	_, off, err := traceVersion(data)
+	if err != nil {
+		return nil, err
+	}
+	type batch struct {
+		gen, m, time uint64
+		data         []byte
+	}
+	var batches []batch
+	freqs := make(map[uint64]float64) // nanoseconds per timestamp unit, by generation
+	u := &uvarints{data: data, off: off}
+	for u.off < len(data) && u.err == nil {
+		typ := data[u.off]
+		u.off++
+		switch typ {
+		case evEndOfGeneration:
+			continue
+		case evExperimentalBatch:
+			u.off++ // experiment ID
+		case evEventBatch:
+		default:
+			return nil, fmt.Errorf("expected a batch at offset %d, found event type %d", u.off-1, typ)
+		}
+		b := batch{gen: u.next(), m: u.next(), time: u.next()}
+		size := int(u.next())
+		if u.err != nil || u.off+size > len(data) {
+			return nil, errors.New("trace is truncated")
+		}
+		b.data = data[u.off : u.off+size]
+		u.off += size
+		if typ == evExperimentalBatch || len(b.data) == 0 {
+			continue
+		}
+		switch b.data[0] {
+		case evStrings, evStacks, evCPUSamples:
+		case evFrequency, evSync:
+			// Before Go 1.25, a lone Frequency; after, Sync followed by Frequency and ClockSnapshot.
+			s := &uvarints{data: b.data}
+			if b.data[0] == evSync {
+				s.off++
+			}
+			for s.off < len(s.data) && s.err == nil {
+				ev := s.data[s.off]
+				s.off++
+				if ev == evFrequency {
+					if f := s.next(); f != 0 {
+						freqs[b.gen] = 1e9 / float64(f)
+					}
+				} else if ev == evClockSnapshot {
+					for i := 0; i < traceArgs[evClockSnapshot]; i++ {
+						s.next()
+					}
+				} else {
+					break
+				}
+			}
+		default:
+			batches = append(batches, b)
+		}
+	}
+	if u.err != nil {
+		return nil, u.err
+	}
+
+	var events []traceEvent
+	for _, b := range batches {
+		freq := freqs[b.gen]
+		if freq == 0 {
+			return nil, fmt.Errorf("no frequency for generation %d", b.gen)
+		}
+		ts := b.time
+		e := &uvarints{data: b.data}
+		for e.off < len(b.data) && e.err == nil {
+			typ := b.data[e.off]
+			e.off++
+			if int(typ) >= len(traceArgs) || traceArgs[typ] == 0 {
+				return nil, fmt.Errorf("unknown event type %d", typ)
+			}
+			ts += e.next()
+			ev := traceEvent{time: int64(float64(ts) * freq), m: b.m, typ: typ}
+			for i := 1; i < traceArgs[typ]; i++ {
+				ev.args[i-1] = e.next()
+			}
+			events = append(events, ev)
+		}
+		if e.err != nil {
+			return nil, e.err
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool { return events[i].time < events[j].time })
+	return events, nil
+}
+
+// summarizeTrace reads and summarizes the execution trace in file.
+func summarizeTrace(file string) (*traceSummary, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	events, err := readTraceEvents(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return nil, errors.New("trace has no events")
+	}
+
+	st := &traceSummary{duration: events[len(events)-1].time - events[0].time}
+	running := make(map[uint64]uint64) // goroutine running on each M
+	runnable := make(map[uint64]int64) // time each runnable goroutine became runnable
+	live := make(map[uint64]bool)
+	gcStart, stwStart := int64(-1), int64(-1)
+	ready := func(g uint64, t int64) {
+		if g != 0 {
+			runnable[g] = t
+		}
+	}
+	for _, e := range events {
+		t, g := e.time, running[e.m]
+		switch e.typ {
+		case evGoStatus, evGoStatusStack:
+			// At the start of each generation, for goroutines that exist.
+			live[e.args[0]] = true
+			switch e.args[2] {
+			case goRunnable:
+				if _, ok := runnable[e.args[0]]; !ok {
+					ready(e.args[0], t)
+				}
+			case goRunning, goSyscall:
+				running[e.args[1]] = e.args[0]
+			}
+		case evGoCreate, evGoCreateBlocked, evGoCreateSyscall:
+			st.goroutines++
+			live[e.args[0]] = true
+			switch e.typ {
+			case evGoCreate:
+				ready(e.args[0], t)
+			case evGoCreateSyscall:
+				running[e.m] = e.args[0]
+			}
+		case evGoStart:
+			if r, ok := runnable[e.args[0]]; ok {
+				st.latencies = append(st.latencies, t-r)
+				delete(runnable, e.args[0])
+			}
+			running[e.m] = e.args[0]
+		case evGoStop, evGoSyscallEndBlocked:
+			ready(g, t)
+			delete(running, e.m)
+		case evGoBlock:
+			delete(running, e.m)
+		case evGoUnblock:
+			ready(e.args[0], t)
+		case evGoDestroy, evGoDestroySyscall:
+			delete(live, g)
+			delete(running, e.m)
+		case evGoSwitch, evGoSwitchDestroy:
+			if e.typ == evGoSwitchDestroy {
+				delete(live, g)
+			}
+			delete(runnable, e.args[0])
+			running[e.m] = e.args[0]
+		case evSTWBegin:
+			stwStart = t
+		case evSTWEnd:
+			if stwStart >= 0 {
+				st.stwTime += t - stwStart
+				stwStart = -1
+			}
+		case evGCActive, evGCBegin:
+			if gcStart < 0 {
+				gcStart = t
+			}
+		case evGCEnd:
+			if gcStart >= 0 {
+				st.gcTime += t - gcStart
+				gcStart = -1
+			}
+		}
+		if len(live) > st.maxLive {
+			st.maxLive = len(live)
+		}
+	}
+	if gcStart >= 0 {
+		st.gcTime += events[len(events)-1].time - gcStart
+	}
+	sort.Slice(st.latencies, func(i, j int) bool { return st.latencies[i] < st.latencies[j] })
+	return st, nil
+}
+
+// latency returns the q quantile of the scheduling latencies in st.
+func (st *traceSummary) latency(q float64) int64 {
+	if len(st.latencies) == 0 {
+		return 0
+	}
+	return st.latencies[int(q*float64(len(st.latencies)-1))]
+}
+
+// traceLine returns the benchmark-format line reporting st for a run of
+// benchmark b.  The testing package marks no boundaries between Go
+// benchmarks in the trace, so the summary, and the line, is for
+// everything the test binary did in that run.
+func traceLine(b *Benchmark, st *traceSummary) string {
+	gc := 0.0
+	if st.duration > 0 {
+		gc = 100 * float64(st.gcTime) / float64(st.duration)
+	}
+	return fmt.Sprintf("Benchmark%s 1 %d sched-latency-p50-ns/op %d sched-latency-p99-ns/op %d sched-latency-max-ns/op %.2f gc-%%/op %d stw-ns/op %d goroutines/op %d max-goroutines/op\n",
+		strings.Title(b.Name), st.latency(0.5), st.latency(0.99), st.latency(1), gc, st.stwTime, st.goroutines, st.maxLive)
+}
+
+// traceName returns the file name, within the profile directory, of the
+// execution trace for iteration i of benchmark b in configuration c.
+func (c *Configuration) traceName(b *Benchmark, i int) string {
+	return c.Name + "." + b.Name + "." + strconv.Itoa(i) + ".trace"
+}
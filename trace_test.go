@@ -0,0 +1,76 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"io/ioutil"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// testdata/small.trace is the execution trace, written by Go 1.27, of a
+// program that starts four goroutines that allocate, waits for them, and
+// then calls runtime.GC.
+
+func TestReadTraceEvents(t *testing.T) {
+	small, err := ioutil.ReadFile("testdata/small.trace")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name   string
+		data   []byte
+		counts map[byte]int // number of events of some types
+		err    string       // if not "", a substring of the expected error
+	}{
+		{"small", small, map[byte]int{evGoCreate: 8, evGoStart: 32, evGCBegin: 9, evGCEnd: 9, evSTWBegin: 19}, ""},
+		{"header only", small[:16], map[byte]int{evGoCreate: 0}, ""},
+		{"truncated", small[:len(small)/2], nil, "truncated"},
+		{"go 1.21", []byte("go 1.21 trace\x00\x00\x00\x01\x02\x03"), nil, "go 1.21 is not supported"},
+		{"not a trace", []byte("goroutine 1 [running]:\n"), nil, "not a Go execution trace"},
+	}
+	for _, tt := range tests {
+		events, err := readTraceEvents(tt.data)
+		if tt.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("%s: error %v, want one containing %q", tt.name, err, tt.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if !sort.SliceIsSorted(events, func(i, j int) bool { return events[i].time < events[j].time }) {
+			t.Errorf("%s: events are not ordered by time", tt.name)
+		}
+		counts := make(map[byte]int)
+		for _, e := range events {
+			counts[e.typ]++
+		}
+		for typ, want := range tt.counts {
+			if counts[typ] != want {
+				t.Errorf("%s: %d events of type %d, want %d", tt.name, counts[typ], typ, want)
+			}
+		}
+	}
+}
+
+func TestSummarizeTrace(t *testing.T) {
+	st, err := summarizeTrace("testdata/small.trace")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st.goroutines != 8 || st.maxLive != 11 || len(st.latencies) != 32 {
+		t.Errorf("goroutines %d, max live %d, %d latencies; want 8, 11, 32", st.goroutines, st.maxLive, len(st.latencies))
+	}
+	if st.duration <= 0 || st.gcTime <= 0 || st.gcTime > st.duration || st.stwTime <= 0 || st.stwTime > st.gcTime {
+		t.Errorf("duration %d, GC time %d, STW time %d; want 0 < STW <= GC <= duration", st.duration, st.gcTime, st.stwTime)
+	}
+	if _, err := summarizeTrace("testdata/missing.trace"); err == nil {
+		t.Errorf("summarizeTrace of a missing file succeeded")
+	}
+}
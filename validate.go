@@ -0,0 +1,238 @@
+// Copyright 2020 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A problem is something wrong with a benchmark or configuration file.
+// Errors would cause bent to fail or misbehave; other problems
+// (unknown keys, missing commands) are reported as warnings.
+type problem struct {
+	file string
+	line int // 0 if unknown
+	err  bool
+	msg  string
+}
+
+func (p problem) String() string {
+	kind := "warning"
+	if p.err {
+		kind = "error"
+	}
+	if p.line > 0 {
+		return fmt.Sprintf("%s:%d: %s: %s", p.file, p.line, kind, p.msg)
+	}
+	return fmt.Sprintf("%s: %s: %s", p.file, kind, p.msg)
+}
+
+// A keyLine records the line on which a key appears in a TOML file,
+// and the table (header) it appears in.
+type keyLine struct {
+	table string // e.g. "Benchmarks", "" for the top level
+	key   string
+	line  int
+}
+
+var headerRE = regexp.MustCompile(`^\s*\[\[?\s*([A-Za-z0-9_.-]+)\s*\]\]?`)
+var keyRE = regexp.MustCompile(`^\s*([A-Za-z0-9_-]+)\s*=`)
+
+// scanKeys finds the header and key lines in the TOML text blob.
+// It only understands the subset of TOML used in bent's files,
+// which is enough for attaching line numbers to problems.
+func scanKeys(blob string) (headers, keys []keyLine) {
+	table := ""
+	for i, l := range strings.Split(blob, "\n") {
+		if m := headerRE.FindStringSubmatch(l); m != nil {
+			table = m[1]
+			headers = append(headers, keyLine{table: table, line: i + 1})
+		} else if m := keyRE.FindStringSubmatch(l); m != nil {
+			keys = append(keys, keyLine{table: table, key: m[1], line: i + 1})
+		}
+	}
+	return
+}
+
+// recordOrigins notes the file and header line of each benchmark,
+// configuration and matrix in todo, which was read from file
+// containing blob, and reports any keys in blob that toml could not
+// decode into todo.
+func (todo *Todo) recordOrigins(file, blob string, undecoded []string) {
+	headers, keys := scanKeys(blob)
+	b, c, m := 0, 0, 0
+	for _, h := range headers {
+		switch h.table {
+		case "Benchmarks":
+			if b < len(todo.Benchmarks) {
+				todo.Benchmarks[b].file, todo.Benchmarks[b].line = file, h.line
+			}
+			b++
+		case "Configurations":
+			if c < len(todo.Configurations) {
+				todo.Configurations[c].file, todo.Configurations[c].line = file, h.line
+			}
+			c++
+		case "Matrix":
+			if m < len(todo.Matrix) {
+				todo.Matrix[m].file, todo.Matrix[m].line = file, h.line
+			}
+			m++
+		}
+	}
+	for _, u := range undecoded {
+		dot := strings.LastIndex(u, ".")
+		table, key := "", u
+		if dot >= 0 {
+			table, key = u[:dot], u[dot+1:]
+		}
+		found := false
+		for _, k := range keys {
+			if k.table == table && k.key == key {
+				todo.problems = append(todo.problems, problem{file: file, line: k.line, msg: "unknown key " + u})
+				found = true
+			}
+		}
+		if !found {
+			todo.problems = append(todo.problems, problem{file: file, msg: "unknown key " + u})
+		}
+	}
+}
+
+// validate checks the (Extends-resolved) benchmarks and configurations
+// in todo and returns all the problems found, including any found
+// while reading the files.  cwd is used to locate wrappers and
+// AfterBuild commands given without a path.
+func (todo *Todo) validate(cwd string) []problem {
+	ps := append([]problem(nil), todo.problems...)
+	report := func(file string, line int, isErr bool, format string, args ...interface{}) {
+		ps = append(ps, problem{file: file, line: line, err: isErr, msg: fmt.Sprintf(format, args...)})
+	}
+	checkEnv := func(file string, line int, what, name, field string, env []string) {
+		for _, e := range env {
+			if eq := strings.IndexByte(e, '='); eq <= 0 {
+				report(file, line, true, "%s %s: %s entry %q is not of the form VAR=value", what, name, field, e)
+			}
+		}
+	}
+	checkCommand := func(file string, line int, what, name, field, cmd string) {
+		if cmd == "" {
+			report(file, line, true, "%s %s: %s has an empty command", what, name, field)
+			return
+		}
+		if !strings.HasPrefix(cmd, "/") {
+			cmd = cwd + "/" + cmd
+		}
+		cmd = os.ExpandEnv(cmd)
+		if _, err := os.Stat(cmd); err != nil {
+			report(file, line, false, "%s %s: %s command %s does not exist", what, name, field, cmd)
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, b := range todo.Benchmarks {
+		if b.Name == "" {
+			report(b.file, b.line, true, "benchmark has no Name")
+		} else if seen[b.Name] {
+			report(b.file, b.line, true, "duplicate benchmark %s", b.Name)
+		}
+		seen[b.Name] = true
+		if strings.Trim(b.Repo, "/") == "" {
+			report(b.file, b.line, true, "benchmark %s has no Repo", b.Name)
+		}
+		if _, err := regexp.Compile(b.Tests); err != nil {
+			report(b.file, b.line, true, "benchmark %s: Tests is not a valid regexp: %v", b.Name, err)
+		}
+		if _, err := regexp.Compile(b.Benchmarks); err != nil {
+			report(b.file, b.line, true, "benchmark %s: Benchmarks is not a valid regexp: %v", b.Name, err)
+		}
+		checkEnv(b.file, b.line, "benchmark", b.Name, "GcEnv", b.GcEnv)
+		checkEnv(b.file, b.line, "benchmark", b.Name, "RunEnv", b.RunEnv)
+		if b.BenchTime != "" && !validBenchTime(b.BenchTime) {
+			report(b.file, b.line, true, "benchmark %s: BenchTime %q is neither a duration nor a count like 100x", b.Name, b.BenchTime)
+		}
+		if b.Count < 0 {
+			report(b.file, b.line, true, "benchmark %s: Count %d is negative", b.Name, b.Count)
+		}
+		if len(b.RunWrapper) > 0 {
+			checkCommand(b.file, b.line, "benchmark", b.Name, "RunWrapper", b.RunWrapper[0])
+		}
+	}
+	seen = make(map[string]bool)
+	for _, c := range todo.Configurations {
+		if c.Name == "" {
+			report(c.file, c.line, true, "configuration has no Name")
+		} else if seen[c.Name] {
+			report(c.file, c.line, true, "duplicate configuration %s", c.Name)
+		}
+		seen[c.Name] = true
+		checkEnv(c.file, c.line, "configuration", c.Name, "GcEnv", c.GcEnv)
+		checkEnv(c.file, c.line, "configuration", c.Name, "RunEnv", c.RunEnv)
+		if len(c.RunWrapper) > 0 {
+			checkCommand(c.file, c.line, "configuration", c.Name, "RunWrapper", c.RunWrapper[0])
+		}
+		for _, cmd := range c.AfterBuild {
+			checkCommand(c.file, c.line, "configuration", c.Name, "AfterBuild", cmd)
+		}
+		if t := c.Target; t != "" && (strings.Count(t, "/") > 1 || strings.HasPrefix(t, "/") || strings.HasSuffix(t, "/")) {
+			report(c.file, c.line, true, "configuration %s: Target %q is not of the form GOOS/GOARCH or GOARCH", c.Name, t)
+		}
+		if len(c.Copy) > 0 && len(c.Executor) == 0 {
+			report(c.file, c.line, true, "configuration %s: Copy is given without an Executor", c.Name)
+		}
+		for _, kind := range c.Profile {
+			if profileFlags[kind] == "" {
+				report(c.file, c.line, true, "configuration %s: unknown Profile %q, should be one of cpu, mem, block, mutex", c.Name, kind)
+			}
+		}
+		if (len(c.Profile) > 0 || c.Trace) && len(c.Executor) > 0 {
+			report(c.file, c.line, false, "configuration %s: profiles and traces are not collected from binaries run by an Executor", c.Name)
+		}
+		if c.PGO && len(c.Executor) > 0 {
+			report(c.file, c.line, true, "configuration %s: PGO profiles are not collected from binaries run by an Executor, so PGO cannot be used with Executor", c.Name)
+		}
+	}
+	return ps
+}
+
+// validBenchTime reports whether s is acceptable to -test.benchtime.
+func validBenchTime(s string) bool {
+	if strings.HasSuffix(s, "x") {
+		n, err := strconv.Atoi(s[:len(s)-1])
+		return err == nil && n > 0
+	}
+	_, err := time.ParseDuration(s)
+	return err == nil
+}
+
+// validateMain implements "bent validate", which reads the benchmark and
+// configuration files, prints every problem found, and returns the exit code.
+func validateMain() int {
+	todo, err := loadTodo()
+	if err != nil {
+		fmt.Println(err)
+		return 1
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		fmt.Printf("Could not get current working directory, %v\n", err)
+		return 1
+	}
+	ps := todo.validate(cwd)
+	for _, p := range ps {
+		fmt.Println(p)
+	}
+	if len(ps) > 0 {
+		return 1
+	}
+	fmt.Printf("%s and %s: %d benchmarks, %d configurations, no problems found\n",
+		benchFile, confFile, len(todo.Benchmarks), len(todo.Configurations))
+	return 0
+}